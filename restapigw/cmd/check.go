@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/api"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
@@ -56,13 +57,29 @@ func checkAndLoad(cmd *cobra.Command, args []string) (*config.ServiceConfig, err
 	return sConf, err
 }
 
+// checkDefinitions - 지정된 시스템 설정의 Repository에서 API 설정을 로드해서 Endpoint 별 설정 검증
+func checkDefinitions(cmd *cobra.Command, sConf *config.ServiceConfig) error {
+	cmd.Printf("[CHECK] Loading API definitions: %s\n", sConf.Repository.DSN)
+	repo, err := api.BuildRepository(sConf, 0)
+	if err != nil {
+		return err
+	}
+	return repo.Close()
+}
+
 // checkFunc - 지정된 args 에서 설정과 관련된 정보를 로드/검증/출력 처리
 func checkFunc(cmd *cobra.Command, args []string) {
 	var (
-		err error
+		sConf *config.ServiceConfig
+		err   error
 	)
 
-	if _, err = checkAndLoad(cmd, args); err != nil {
+	sConf, err = checkAndLoad(cmd, args)
+	if err == nil {
+		// API 설정 검증 (Repository 로드 과정에서 Endpoint 별 설정 검증)
+		err = checkDefinitions(cmd, sConf)
+	}
+	if err != nil {
 		fmt.Printf("[CHECK - ERROR] %s \n", err)
 		os.Exit(1)
 		return
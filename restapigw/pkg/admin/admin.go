@@ -90,6 +90,13 @@ func (s *Server) addInternalRoutes(ge *gin.Engine, guard jwt.Guard) {
 		groupAPI.DELETE("/group/:gid/definition/:id", gin.WrapH(s.apiHandler.RemoveDefinition())) // Remove Definition
 	}
 
+	// Circuit Breaker 상태 (Backend Host 정보를 포함하므로 인증 필요)
+	breakerAPI := ge.Group("/status/breakers")
+	breakerAPI.Use(ginAdapter.Wrap(jwt.NewMiddleware(guard).Handler))
+	{
+		breakerAPI.GET("", gin.WrapH(NewBreakersHandler()))
+	}
+
 	if s.profilingEnabled {
 		groupProfiler := ge.Group("/debug/pprof")
 		if !s.profilingPublic {
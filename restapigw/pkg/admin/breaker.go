@@ -0,0 +1,22 @@
+// Package admin -
+package admin
+
+import (
+	"net/http"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/admin/response"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/sd"
+)
+
+// ===== [ Constants and Variables ] =====
+// ===== [ Types ] =====
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// NewBreakersHandler - Backend Host 별 Circuit Breaker 상태 조회용 핸들러 구성
+func NewBreakersHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		response.Write(rw, req, sd.BreakerStatuses())
+	}
+}
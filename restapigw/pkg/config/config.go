@@ -2,7 +2,10 @@
 package config
 
 import (
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
@@ -12,6 +15,7 @@ import (
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core/defaults"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/encoding"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
+	"github.com/jmespath/go-jmespath"
 )
 
 // ===== [ Constants and Variables ] =====
@@ -19,6 +23,8 @@ import (
 const (
 	// ConfigVersion - 설정 구조에 대한 버전
 	ConfigVersion = 1
+	// DefaultMaxBodySize - Request Body를 메모리에 보관하는 경우의 기본 최대 크기 (1MB)
+	DefaultMaxBodySize = 1 << 20
 )
 
 const (
@@ -31,9 +37,47 @@ const (
 var (
 	jwtAlgorithms = []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512"}
 	encodings     = []string{"no-op", "json", "string"}
+	// 상태 코드 또는 상태 코드 범위 ("2xx") 식별용 정규식
+	reStatusCode = regexp.MustCompile(`^[1-5](\d\d|xx)$`)
+
+	// Endpoint 응답에만 사용 가능한 인코딩 포함
+	outputEncodings = []string{"no-op", "json", "string", "template"}
+
+	flatmapCastTypes = []string{"string", "number", "int", "bool"}
+
+	// JWT 토큰 추출 위치 유형
+	jwtTokenSources = []string{"header", "query", "cookie"}
+
+	// fanout 항목 호출 실패시 처리 방식
+	fanoutFailures = []string{"partial", "fail", "ignore"}
+
+	// FlatmapOps - flatmap_filter (Backend Response 변환) 처리 유형 별 인자 개수
+	FlatmapOps = OpArgs{
+		"move":    {2, 2},
+		"del":     {1, -1},
+		"copy":    {2, 2},
+		"set":     {1, 1},
+		"append":  {2, -1},
+		"rename":  {2, 2},
+		"flatten": {1, 1},
+		"cast":    {2, 2},
+	}
+
+	// RequestTransformOps - request_transform (Backend Request Body 변환) 처리 유형 별 인자 개수
+	RequestTransformOps = OpArgs{
+		"whitelist": {1, -1},
+		"del":       {1, -1},
+		"move":      {2, 2},
+		"rename":    {2, 2},
+		"set":       {1, 1},
+		"template":  {1, 1},
+	}
 
 	errInvalidNoOpEncoding = errors.New("can not use NoOp encoding with more than one backends connected to the same endpoint")
 
+	// Streaming 종료를 위해 서비스 Write Timeout 에서 확보할 여유 시간
+	streamWriteTimeoutMargin = time.Second
+
 	// ErrNoHosts - Load Balancing 처리 대상 Host 가 지정되지 않은 경우 오류
 	ErrNoHosts = errors.New("no available hosts")
 )
@@ -50,6 +94,9 @@ type (
 	// LBModes - Load Balancing Mode 유형 형식
 	LBModes int
 
+	// OpArgs - Flatmap 기반 변환 처리 유형 별 인자 개수 형식 ({최소, 최대}, 최대가 -1 이면 제한 없음)
+	OpArgs map[string][2]int
+
 	// ServiceConfig - REST API Gateway 운영에 필요한 서비스 설정 형식
 	ServiceConfig struct {
 		// 서비스 식별 명 (기본값: '')
@@ -185,8 +232,20 @@ type (
 		Middleware MWConfig `yaml:"middleware" json:"middleware"`
 		// HealthCheck - Health Check 설정
 		HealthCheck *HealthCheck `yaml:"health_check" json:"health_check" default:"{}"`
+		// StatusMapping - Backend 처리 결과의 오류 상태 코드를 클라이언트 응답 상태 코드와 Body로 변환하는 규칙 리스트 (기본값: "[]")
+		StatusMapping []*StatusMappingConfig `yaml:"status_mapping" json:"status_mapping"`
+		// Template - OutputEncoding 이 "template" 인 경우 응답 생성에 사용할 Template 설정
+		Template *TemplateConfig `yaml:"template" json:"template"`
+		// Streaming - Backend 응답을 수신되는 대로 클라이언트로 전달 (Flush) 하는 Streaming 설정 (기본값: 없음, "no-op" 인코딩만 지원)
+		Streaming *StreamingConfig `yaml:"streaming" json:"streaming"`
+		// WebSocket - WebSocket (HTTP Upgrade) 연결 중계 설정 (기본값: 없음, Bypass Endpoint는 설정 없이도 중계)
+		WebSocket *WebSocketConfig `yaml:"websocket" json:"websocket"`
+		// JWT - Backend 조작 규칙 (headers, query) 의 "{jwt.<claim>}" 변수에 사용할 클라이언트 JWT 검증 설정 (기본값: 없음, 미 지정시 "{jwt.<claim>}" 사용 불가)
+		JWT *JWTConfig `yaml:"jwt" json:"jwt"`
 		// Backend - Endpoint에서 호출할 Backend API 서버 호출/응답 처리 설정 리스트
 		Backend []*BackendConfig `yaml:"backend" json:"backend"`
+		// Split - Backend 구성 (Variant) 간의 트래픽 분할 설정 (기본값: 없음, 어떤 Variant에도 선택되지 않은 요청은 Backend 설정 사용)
+		Split *SplitConfig `yaml:"split" json:"split"`
 
 		// Bypass 처리 여부 (내부 처리용)
 		IsBypass bool `yaml:"-" json:"-"`
@@ -225,11 +284,76 @@ type (
 		HostSanitizationDisabled bool `yaml:"disable_host_sanitize" json:"disable_host_sanitize" default:"false"`
 		// BalanceMode - Backend Loadbalacing 모드 (기본값: "", "rr" - "roundrobin", "wrr" - "weighted roundrobin", "" - random)
 		BalanceMode string `yaml:"lb_mode" json:"lb_mode" default:""`
+		// Required - 여러 Backend를 Merging 하는 경우 해당 Backend 실패시 Endpoint 전체를 실패로 처리할지 여부 (기본값: false, 선택적 Backend)
+		Required bool `yaml:"required" json:"required" default:"false"`
+		// Fallback - 선택적 Backend 실패시 Merging에 대신 사용할 고정 응답 데이터 (기본값: 없음)
+		Fallback map[string]interface{} `yaml:"fallback" json:"fallback"`
+		// RequestHeaders - Backend 호출시 설정할 Header 템플릿 맵 (기본값: "{}", Endpoint 파라미터 및 "{respN_xxx}" 사용 가능)
+		RequestHeaders map[string]string `yaml:"request_headers" json:"request_headers" default:"{}"`
+		// RequestQuery - Backend 호출시 설정할 Query String 템플릿 맵 (기본값: "{}", Endpoint 파라미터 및 "{respN_xxx}" 사용 가능)
+		RequestQuery map[string]string `yaml:"request_query" json:"request_query" default:"{}"`
+		// RequestBody - Backend 호출시 사용할 JSON Body 템플릿 (기본값: "", 미 지정시 Client Body 사용, Endpoint 파라미터 및 "{respN_xxx}" 사용 가능)
+		RequestBody string `yaml:"request_body" json:"request_body" default:""`
+		// HeaderRules - Backend 호출시 적용할 Header 조작 규칙 (기본값: 없음)
+		HeaderRules *RequestRulesConfig `yaml:"headers" json:"headers"`
+		// QueryRules - Backend 호출시 적용할 Query String 조작 규칙 (기본값: 없음)
+		QueryRules *RequestRulesConfig `yaml:"query" json:"query"`
+		// DependsOn - DAG Merging (mw-proxy.dag) 에서 먼저 완료되어야 하는 이전 Backend 순서 리스트 (기본값: "[]", 0 부터 시작)
+		DependsOn []int `yaml:"depends_on" json:"depends_on" default:"[]"`
+		// Mock - Host 대신 설정된 고정 응답을 반환하는 Mock 설정 (기본값: 없음, Backend에 hosts가 지정되지 않은 경우만 적용)
+		Mock *MockConfig `yaml:"mock" json:"mock"`
+		// Mirror - 요청 복제본을 비동기로 전달할 Shadow Host 설정 (기본값: 없음, Shadow 응답은 폐기하고 Metrics로만 수집)
+		Mirror *MirrorConfig `yaml:"mirror" json:"mirror"`
+		// Retry - Backend 호출 실패시 다른 Host로 재 시도하는 설정 (기본값: 없음)
+		Retry *RetryConfig `yaml:"retry" json:"retry"`
+		// CircuitBreaker - Host 별 호출 실패 상태에 따라 Load Balancing 대상에서 제외하는 Circuit Breaker 설정 (기본값: 없음)
+		CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker" json:"circuit_breaker"`
+		// Hedge - 응답이 지연되는 경우 다른 Host로 추가 요청을 전달하는 Hedged Request 설정 (기본값: 없음, GET 메서드와 여러 Host 인 경우만 사용 가능)
+		Hedge *HedgeConfig `yaml:"hedge" json:"hedge"`
+		// PropagateDeadline - Backend 호출시 남은 처리 시간을 X-Request-Deadline Header (milliseconds) 로 전달할지 여부 (기본값: false)
+		PropagateDeadline bool `yaml:"propagate_deadline" json:"propagate_deadline" default:"false"`
 
 		// API 호출의 응답을 파싱하기 위한 디코더 (내부 사용)
 		Decoder encoding.Decoder `yaml:"-" json:"-"`
 		// URLPattern에서 파라미터 변환에 사용할 키 관리 (내부 사용)
 		URLKeys []string `yaml:"-" json:"-"`
+		// Retry, Hedge, Mirror, Request Body 변환에서 Body 보관시 허용할 최대 크기 (Endpoint의 mw-proxy.max_body_size, 내부 사용)
+		MaxBodySize int64 `yaml:"-" json:"-"`
+		// Endpoint에 상태 코드 변환 규칙 (status_mapping) 이 지정되어 Backend 오류 상태 코드를 유지할지 여부 (내부 사용)
+		KeepStatusCode bool `yaml:"-" json:"-"`
+		// Header, Query String 조작 규칙의 "{jwt.<claim>}" 변수에 사용할 JWT 검증 설정 (Endpoint의 jwt, 내부 사용)
+		JWT *JWTConfig `yaml:"-" json:"-"`
+	}
+
+	// RequestRulesConfig - Backend 호출시 Header 또는 Query String 조작 규칙 구조 (rename, remove, set, add 순서로 적용)
+	// (값에는 Endpoint 파라미터, "{respN_xxx}", "{client_ip}", "{request_id}", "{jwt.<claim>}" 사용 가능)
+	RequestRulesConfig struct {
+		// Rename - 이름을 변경할 항목 맵 (기존 이름: 새 이름)
+		Rename map[string]string `yaml:"rename" json:"rename"`
+		// Remove - 제거할 항목 이름 리스트
+		Remove []string `yaml:"remove" json:"remove"`
+		// Set - 값을 교체할 항목 맵 (없는 경우는 추가)
+		Set map[string]string `yaml:"set" json:"set"`
+		// Add - 기존 값에 추가할 항목 맵
+		Add map[string]string `yaml:"add" json:"add"`
+	}
+
+	// JWTConfig - 클라이언트 JWT의 서명과 만기 시간을 검증해서 Claim 정보를 사용하기 위한 설정 구조
+	JWTConfig struct {
+		// SigningMethods - 서명 검증에 사용할 알고리즘과 키 리스트 (필수)
+		SigningMethods []*JWTSigningMethodConfig `yaml:"signing_methods" json:"signing_methods"`
+		// TokenLookup - 토큰을 추출할 위치 ("<source>:<name>", source 는 header, query, cookie) (기본값: "header:Authorization")
+		TokenLookup string `yaml:"token_lookup" json:"token_lookup" default:"header:Authorization"`
+		// Leeway - 만기 시간 (exp, iat, nbf) 검증시 허용할 오차 (기본값: 0)
+		Leeway time.Duration `yaml:"leeway" json:"leeway"`
+	}
+
+	// JWTSigningMethodConfig - JWT 서명 검증 알고리즘과 키 구조
+	JWTSigningMethodConfig struct {
+		// Alg - 서명 알고리즘 (HS256, HS384, HS512, RS256, RS384, RS512)
+		Alg string `yaml:"alg" json:"alg"`
+		// Key - 서명 검증 키 (HS 계열은 비밀 키, RS 계열은 PEM 형식의 공개 키)
+		Key string `yaml:"key" json:"key"`
 	}
 
 	// HostConfig - Backend Load balancing 처리를 위한 Host 구조
@@ -275,6 +399,128 @@ type (
 		Timeout time.Duration `mapstructure:"timeout" yaml:"timeout" json:"timeout" bson:"timeout" default:"0s"`
 	}
 
+	// StatusMappingConfig - 상태 코드 변환 규칙 구조
+	StatusMappingConfig struct {
+		// From - 변환 대상 Backend 처리 결과 상태 코드
+		From int `yaml:"from" json:"from"`
+		// To - 클라이언트로 반환할 상태 코드
+		To int `yaml:"to" json:"to"`
+		// Body - 반환할 Body 데이터 (기본값: 없음, 미 지정시 처리 결과 데이터 유지, 배열인 경우는 배열로 반환)
+		Body interface{} `yaml:"body" json:"body"`
+	}
+
+	// MockConfig - Backend 호출 대신 반환할 고정 응답 구조
+	MockConfig struct {
+		// StatusCode - 응답 상태 코드 (기본값: 200)
+		StatusCode int `yaml:"status" json:"status" default:"200"`
+		// Headers - 응답 Header 맵 (기본값: "{}")
+		Headers map[string]string `yaml:"headers" json:"headers" default:"{}"`
+		// Body - 응답 Body 데이터 (기본값: 없음, 문자열은 그대로 사용하고 그 외는 JSON으로 변환)
+		Body interface{} `yaml:"body" json:"body"`
+		// File - 응답 Body로 사용할 파일 경로 (기본값: "", Body와 함께 지정할 수 없음)
+		File string `yaml:"file" json:"file"`
+	}
+
+	// MirrorConfig - Traffic Mirroring (Shadowing) 을 위한 Shadow Host 설정 구조
+	MirrorConfig struct {
+		// Hosts - Shadow 요청을 전달할 Host 리스트 (필수)
+		Hosts []*HostConfig `yaml:"hosts" json:"hosts"`
+		// BalanceMode - Shadow Host Load Balancing 모드 (기본값: "", Backend 의 lb_mode 와 동일)
+		BalanceMode string `yaml:"lb_mode" json:"lb_mode" default:""`
+		// Timeout - Shadow 요청 처리 시간 (기본값: 없으면 Backend 의 timeout 사용)
+		Timeout time.Duration `yaml:"timeout" json:"timeout"`
+		// Diff - Primary 응답 데이터와 Shadow 응답 데이터의 일치 여부 비교 (기본값: false)
+		Diff bool `yaml:"diff" json:"diff" default:"false"`
+		// MaxConcurrency - 동시에 처리할 수 있는 Shadow 요청의 최대 수 (기본값: 100, 초과하는 요청은 복제 생략)
+		MaxConcurrency int `yaml:"max_concurrency" json:"max_concurrency" default:"100"`
+	}
+
+	// RetryConfig - Backend 호출 실패시 재 시도 설정 구조
+	RetryConfig struct {
+		// MaxAttempts - 최초 호출을 포함한 최대 호출 횟수 (기본값: 3)
+		MaxAttempts int `yaml:"max_attempts" json:"max_attempts" default:"3"`
+		// Backoff - 재 시도 전 대기 시간 (기본값: 100ms, 재 시도마다 2배씩 증가하고 Jitter 적용)
+		Backoff time.Duration `yaml:"backoff" json:"backoff" default:"100ms"`
+		// MaxBackoff - 재 시도 전 대기 시간의 최대 값 (기본값: 1s)
+		MaxBackoff time.Duration `yaml:"max_backoff" json:"max_backoff" default:"1s"`
+		// StatusCodes - 재 시도할 Backend 응답 상태 코드 리스트 (기본값: "[502,503,504]", 연결 오류 등의 Network 오류는 항상 재 시도)
+		StatusCodes []int `yaml:"status_codes" json:"status_codes" default:"[502,503,504]"`
+		// AllowNonIdempotent - POST, PATCH 등 멱등성이 보장되지 않는 메서드도 재 시도할지 여부 (기본값: false)
+		AllowNonIdempotent bool `yaml:"allow_non_idempotent" json:"allow_non_idempotent" default:"false"`
+	}
+
+	// CircuitBreakerConfig - Backend Host 별 Circuit Breaker 설정 구조 (Closed -> Open -> Half-Open -> Closed)
+	CircuitBreakerConfig struct {
+		// ConsecutiveFailures - Circuit을 Open 할 연속 실패 횟수 (기본값: 5, 0 이면 적용하지 않음)
+		ConsecutiveFailures int `yaml:"consecutive_failures" json:"consecutive_failures" default:"5"`
+		// FailureRatio - Circuit을 Open 할 실패 비율 (기본값: 0, 0 ~ 1, 0 이면 적용하지 않음)
+		FailureRatio float64 `yaml:"failure_ratio" json:"failure_ratio" default:"0"`
+		// MinRequests - 실패 비율을 적용하기 위한 산정 주기 내의 최소 호출 수 (기본값: 10)
+		MinRequests int `yaml:"min_requests" json:"min_requests" default:"10"`
+		// Interval - 실패 비율 산정 주기 (기본값: 60s, 주기마다 호출/실패 수 초기화)
+		Interval time.Duration `yaml:"interval" json:"interval" default:"60s"`
+		// CoolDown - Open 상태를 유지할 시간 (기본값: 30s, 이후 Half-Open 상태로 전환하여 시험 호출)
+		CoolDown time.Duration `yaml:"cool_down" json:"cool_down" default:"30s"`
+		// HalfOpenRequests - Half-Open 상태에서 허용할 시험 호출 수 (기본값: 1, 모두 성공하면 Closed 상태로 전환)
+		HalfOpenRequests int `yaml:"half_open_requests" json:"half_open_requests" default:"1"`
+	}
+
+	// HedgeConfig - 지연되는 요청을 다른 Host로 추가 전달하고 먼저 성공한 응답을 사용하는 Hedged Request 설정 구조
+	HedgeConfig struct {
+		// Delay - 추가 요청을 전달하기 전에 대기할 시간 (기본값: 0, Percentile 지정시는 응답 시간 정보가 충분히 수집되기 전까지만 사용)
+		Delay time.Duration `yaml:"delay" json:"delay"`
+		// Percentile - 최근 응답 시간의 지정한 Percentile 값을 대기 시간으로 사용 (기본값: 0, 0 이면 Delay 사용, 예: 95)
+		Percentile float64 `yaml:"percentile" json:"percentile"`
+		// MaxRatio - 전체 요청 대비 추가 요청 비율의 최대 값 (기본값: 0.1, 0 ~ 1)
+		MaxRatio float64 `yaml:"max_ratio" json:"max_ratio" default:"0.1"`
+	}
+
+	// SplitConfig - Canary 배포 등을 위한 Backend 구성 (Variant) 간의 트래픽 분할 설정 구조
+	SplitConfig struct {
+		// By - 분할 방식 (기본값: "percentage", "percentage" - 가중치 비율, "match" - Key 값 일치, "hash" - Key 값의 Hash 기준 가중치 비율 (고정 배정))
+		By string `yaml:"by" json:"by" default:"percentage"`
+		// Key - "match", "hash" 방식에 사용할 요청 값 ("header:<name>", "cookie:<name>", "ip")
+		Key string `yaml:"key" json:"key"`
+		// Variants - 트래픽을 분할할 Backend 구성 리스트
+		Variants []*VariantConfig `yaml:"variants" json:"variants"`
+	}
+
+	// VariantConfig - 트래픽 분할 대상 Backend 구성 구조
+	VariantConfig struct {
+		// Name - Variant 식별 명 (필수, Metrics 식별에 사용)
+		Name string `yaml:"name" json:"name"`
+		// Weight - "percentage", "hash" 방식에서 배정할 트래픽 비율 (0 ~ 100, 전체 Variant 합계는 100 이하)
+		Weight int `yaml:"weight" json:"weight"`
+		// Values - "match" 방식에서 Variant를 선택할 Key 값 리스트
+		Values []string `yaml:"values" json:"values"`
+		// Backend - Variant에서 호출할 Backend 설정 리스트
+		Backend []*BackendConfig `yaml:"backend" json:"backend"`
+	}
+
+	// TemplateConfig - Go text/template 기반의 응답 생성 설정 구조
+	TemplateConfig struct {
+		// File - Template 파일 경로
+		File string `yaml:"file" json:"file"`
+		// ContentType - 응답 Content-Type (기본값: "text/plain; charset=utf-8")
+		ContentType string `yaml:"content_type" json:"content_type"`
+		// HeadersToPass - Template 에서 사용할 수 있도록 전달할 Request Header 목록 (기본값: 없음, 지정하지 않은 Header는 전달하지 않음)
+		HeadersToPass []string `yaml:"headers_to_pass" json:"headers_to_pass"`
+	}
+
+	// StreamingConfig - Backend 응답을 Streaming 방식으로 전달하기 위한 설정 구조
+	StreamingConfig struct {
+		// IdleTimeout - Backend에서 데이터 수신 없이 대기할 최대 시간 (기본값: 0, 0이면 제한없음)
+		IdleTimeout time.Duration `yaml:"idle_timeout" json:"idle_timeout"`
+		// MaxDuration - Stream을 유지할 최대 시간 (기본값: 0, 서비스의 write_timeout 이 지정된 경우는 write_timeout 이내로 제한)
+		MaxDuration time.Duration `yaml:"max_duration" json:"max_duration"`
+	}
+
+	// WebSocketConfig - WebSocket (HTTP Upgrade) 연결 중계를 위한 설정 구조
+	WebSocketConfig struct {
+		// IdleTimeout - 양방향 모두 데이터 전송이 없는 경우 연결을 유지할 최대 시간 (기본값: 0, 0이면 제한없음)
+		IdleTimeout time.Duration `yaml:"idle_timeout" json:"idle_timeout"`
+	}
+
 	// UnsupportedVersionError - 설정 초기화 과정에서 버전 검증을 통해 반환할 오류 구조
 	UnsupportedVersionError struct {
 		Have int
@@ -318,6 +564,14 @@ type (
 		Path   string
 		Method string
 	}
+
+	// InvalidDependencyError - Backend 의존관계가 이전 Backend를 지정하지 않았을 경우에 반환할 오류 구조
+	InvalidDependencyError struct {
+		Endpoint   string
+		Method     string
+		Backend    int
+		Dependency int
+	}
 )
 
 // ===== [ Implementations ] =====
@@ -463,6 +717,17 @@ func (e *EndpointPathError) Error() string {
 	return "ERROR: the endpoint url path '" + e.Method + " " + e.Path + "' is not a valid one!!! Ignoring"
 }
 
+// Error - Backend 의존관계 오류 문자열 반환
+func (i *InvalidDependencyError) Error() string {
+	return fmt.Sprintf(
+		"invalid backend dependency. endpoint: %s %s, backend: %d depends on %d (only earlier backends allowed)",
+		i.Method,
+		i.Endpoint,
+		i.Backend,
+		i.Dependency,
+	)
+}
+
 // Error - Backend 미지정 오류 문자열 반환
 func (n *NoBackendsError) Error() string {
 	return "WARNING: the '" + n.Method + " " + n.Path + "' endpoint has 0 backends defined! Ignoring"
@@ -606,6 +871,17 @@ func (eConf *EndpointConfig) InheriteFromService(sConf *ServiceConfig) {
 	if core.IsZeroOfUnderlyingType(eConf.OutputEncoding) {
 		eConf.OutputEncoding = sConf.OutputEncoding
 	}
+
+	// 서비스의 Write Timeout 이 지정된 경우는 연결이 끊기기 전에 Stream을 정상 종료할 수 있도록 최대 유지 시간 제한
+	if eConf.Streaming != nil && sConf.WriteTimeout > 0 {
+		limit := sConf.WriteTimeout
+		if limit > streamWriteTimeoutMargin {
+			limit -= streamWriteTimeoutMargin
+		}
+		if eConf.Streaming.MaxDuration <= 0 || eConf.Streaming.MaxDuration > limit {
+			eConf.Streaming.MaxDuration = limit
+		}
+	}
 }
 
 // InitializeDefaults - Endpoint에 미 설정된 항목들을 기본 값으로 초기화
@@ -649,13 +925,39 @@ func (eConf *EndpointConfig) AdjustValues(sConf *ServiceConfig) error {
 
 	eConf.Endpoint = core.GetParameteredPath(eConf.Endpoint, inputParams)
 
+	// Endpoint 설정의 Middleware 설정 맵 관리
+	eConf.Middleware.sanitize()
+
+	if err := eConf.adjustBackends(inputSet); err != nil {
+		return err
+	}
+
+	// 트래픽 분할 Variant 별 Backend 설정 조정
+	if eConf.Split != nil {
+		for _, v := range eConf.Split.Variants {
+			if v == nil {
+				continue
+			}
+			if err := eConf.VariantEndpoint(v).adjustBackends(inputSet); err != nil {
+				return errors.Wrapf(err, "invalid split variant '%s'", v.Name)
+			}
+		}
+	}
+
+	// 최종 Endpoint 정보 검사
+	if err := eConf.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// adjustBackends - Endpoint에 지정된 Backend 설정들을 사용가능한 정보로 재 구성
+func (eConf *EndpointConfig) adjustBackends(inputSet map[string]interface{}) error {
 	if eConf.OutputEncoding == encoding.NOOP && len(eConf.Backend) > 1 {
 		return errInvalidNoOpEncoding
 	}
 
-	// Endpoint 설정의 Middleware 설정 맵 관리
-	eConf.Middleware.sanitize()
-
 	for bIdx, bConf := range eConf.Backend {
 		if err := eConf.InitBackendDefaults(bIdx); err != nil {
 			return err
@@ -665,16 +967,30 @@ func (eConf *EndpointConfig) AdjustValues(sConf *ServiceConfig) error {
 			return err
 		}
 
+		eConf.InitBackendRequestTemplates(bIdx, inputSet)
+
 		// Backend 설정의 Middleware 설정 맵 관리
 		bConf.Middleware.sanitize()
 	}
+	return nil
+}
 
-	// 최종 Endpoint 정보 검사
-	if err := eConf.Validate(); err != nil {
-		return err
+// MaxBodySize - Endpoint 설정 ("mw-proxy.max_body_size") 에서 Request Body를 메모리에 보관하는 경우 허용할 최대 크기 (bytes) 반환 (미 지정시 1MB)
+func (eConf *EndpointConfig) MaxBodySize() int64 {
+	if e, ok := toStringMap(eConf.Middleware["mw-proxy"]); ok {
+		if size, ok := e["max_body_size"].(int); ok && size > 0 {
+			return int64(size)
+		}
 	}
+	return DefaultMaxBodySize
+}
 
-	return nil
+// VariantEndpoint - 지정한 트래픽 분할 Variant의 Backend 설정을 사용하는 Endpoint 설정 생성 (트래픽 분할 설정 제외)
+func (eConf *EndpointConfig) VariantEndpoint(v *VariantConfig) *EndpointConfig {
+	vConf := *eConf
+	vConf.Backend = v.Backend
+	vConf.Split = nil
+	return &vConf
 }
 
 // InitBackendDefaults - Backend에 미 설정된 항목들을 기본 값으로 초기화
@@ -682,8 +998,10 @@ func (eConf *EndpointConfig) InitBackendDefaults(bIdx int) error {
 	backend := eConf.Backend[bIdx]
 
 	if core.IsZeroOfUnderlyingType(backend.Hosts) && len(backend.Hosts) == 0 {
-		// HOST 미 지정시 전역 URL 사용
-		backend.Hosts = eConf.Hosts
+		// HOST 미 지정시 전역 URL 사용 (Mock 설정이 있는 경우는 Mock 사용)
+		if backend.Mock == nil {
+			backend.Hosts = eConf.Hosts
+		}
 	} else if !backend.HostSanitizationDisabled {
 		cleanHosts(backend.Hosts)
 	}
@@ -698,11 +1016,28 @@ func (eConf *EndpointConfig) InitBackendDefaults(bIdx int) error {
 		backend.Timeout = eConf.Timeout
 	}
 
+	// Body 보관 최대 크기는 Endpoint 설정 사용
+	backend.MaxBodySize = eConf.MaxBodySize()
+	// 상태 코드 변환 규칙이 지정된 경우는 Backend 오류 상태 코드 유지
+	backend.KeepStatusCode = len(eConf.StatusMapping) > 0
+	// JWT Claim 변수는 Endpoint의 JWT 검증 설정 사용
+	backend.JWT = eConf.JWT
+
 	// 생략된 데이터 구성
 	if err := backend.InitializeDefaults(); err != nil {
 		return err
 	}
 
+	// Mirror 설정의 Host 정제 및 Timeout 미 지정시 Backend Timeout 사용
+	if backend.Mirror != nil {
+		if !backend.HostSanitizationDisabled {
+			cleanHosts(backend.Mirror.Hosts)
+		}
+		if core.IsZeroOfUnderlyingType(backend.Mirror.Timeout) {
+			backend.Mirror.Timeout = backend.Timeout
+		}
+	}
+
 	// Backend 처리 결과를 위한 Decoder 구성
 	backend.Decoder = encoding.Get(strings.ToLower(backend.Encoding))(backend.IsCollection, backend.WrapCollectionToJSON)
 
@@ -750,6 +1085,44 @@ func (eConf *EndpointConfig) InitBackendURLMappings(bIdx int, inputParams map[st
 	return nil
 }
 
+// InitBackendRequestTemplates - Backend에 지정된 Header, Query String, Body 템플릿 (조작 규칙 및 Request Body 변환 포함) 의 파라미터 정보들을 이후에 사용할 수 있도록 초기화
+func (eConf *EndpointConfig) InitBackendRequestTemplates(bIdx int, inputParams map[string]interface{}) {
+	backend := eConf.Backend[bIdx]
+
+	for k, v := range backend.RequestHeaders {
+		backend.RequestHeaders[k] = toParamTemplate(v, inputParams)
+	}
+	for k, v := range backend.RequestQuery {
+		backend.RequestQuery[k] = toParamTemplate(v, inputParams)
+	}
+	backend.RequestBody = toParamTemplate(backend.RequestBody, inputParams)
+
+	// Header, Query String 조작 규칙의 값
+	for _, rules := range []*RequestRulesConfig{backend.HeaderRules, backend.QueryRules} {
+		if rules == nil {
+			continue
+		}
+		for k, v := range rules.Set {
+			rules.Set[k] = toParamTemplate(v, inputParams)
+		}
+		for k, v := range rules.Add {
+			rules.Add[k] = toParamTemplate(v, inputParams)
+		}
+	}
+
+	// Request Body 변환 (mw-proxy.request_transform) 의 template 값
+	if e, ok := toStringMap(backend.Middleware["mw-proxy"]); ok {
+		ops, _ := e["request_transform"].([]interface{})
+		for _, v := range ops {
+			if op, ok := toStringMap(v); ok && op["type"] == "template" {
+				if tmpl, ok := op["value"].(string); ok {
+					op["value"] = toParamTemplate(tmpl, inputParams)
+				}
+			}
+		}
+	}
+}
+
 // Validate - Endpoint 별 세부 필수 항목 검증
 func (eConf *EndpointConfig) Validate() error {
 	if eConf.Name == "" {
@@ -769,18 +1142,82 @@ func (eConf *EndpointConfig) Validate() error {
 	}
 
 	// Output Encoding 검믕
-	if !core.ContainsString(encodings, eConf.OutputEncoding) {
+	if !core.ContainsString(outputEncodings, eConf.OutputEncoding) {
 		return errors.New("invalid output encoding")
 	}
+	if eConf.OutputEncoding == encoding.TEMPLATE {
+		if eConf.Template == nil || eConf.Template.File == "" {
+			return errors.New("template file required for template output encoding")
+		}
+	}
+
+	if err := validateQuery(eConf.Middleware); err != nil {
+		return errors.Wrapf(err, "invalid query for endpoint '%s'", eConf.Endpoint)
+	}
+
+	if err := validateBulkhead(eConf.Middleware); err != nil {
+		return errors.Wrapf(err, "invalid mw-bulkhead for endpoint '%s'", eConf.Endpoint)
+	}
+
+	// Streaming은 Backend 응답을 그대로 전달하는 경우만 가능
+	if eConf.Streaming != nil {
+		if eConf.OutputEncoding != encoding.NOOP || len(eConf.Backend) != 1 || eConf.Backend[0].Encoding != encoding.NOOP {
+			return errors.Errorf("streaming endpoint '%s' requires no-op encoding with a single no-op backend", eConf.Endpoint)
+		}
+		if eConf.Streaming.IdleTimeout < 0 || eConf.Streaming.MaxDuration < 0 {
+			return errors.Errorf("invalid streaming timeouts for endpoint '%s'", eConf.Endpoint)
+		}
+	}
+
+	// WebSocket 중계는 단일 Backend의 GET 요청만 가능
+	if eConf.WebSocket != nil {
+		if len(eConf.Backend) != 1 || strings.ToUpper(eConf.Method) != http.MethodGet {
+			return errors.Errorf("websocket endpoint '%s' requires GET method with a single backend", eConf.Endpoint)
+		}
+		if eConf.WebSocket.IdleTimeout < 0 {
+			return errors.Errorf("invalid websocket idle_timeout for endpoint '%s'", eConf.Endpoint)
+		}
+	}
+
+	if err := eConf.JWT.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid jwt for endpoint '%s'", eConf.Endpoint)
+	}
+
+	// 상태 코드 변환 규칙 검증
+	for i, sm := range eConf.StatusMapping {
+		if sm == nil || http.StatusText(sm.From) == "" || http.StatusText(sm.To) == "" {
+			return errors.Errorf("invalid status_mapping[%d] for endpoint '%s'", i, eConf.Endpoint)
+		}
+	}
+
+	// 트래픽 분할 검증
+	if eConf.Split != nil {
+		if err := eConf.validateSplit(); err != nil {
+			return errors.Wrapf(err, "invalid split for endpoint '%s'", eConf.Endpoint)
+		}
+	}
 
 	// Backend 검증
 	if len(eConf.Backend) == 0 {
 		return &NoBackendsError{Path: eConf.Endpoint, Method: eConf.Method}
 	}
-	for _, backend := range eConf.Backend {
+	for bIdx, backend := range eConf.Backend {
 		if err := backend.Validate(); err != nil {
 			return err
 		}
+		if err := validateFanout(bIdx, backend.Middleware, eConf.Middleware); err != nil {
+			return errors.Wrapf(err, "invalid fanout for backend[%d] '%s'", bIdx, backend.URLPattern)
+		}
+		// 검증되지 않은 토큰의 Claim은 사용할 수 없으므로 JWT 검증 설정 필요
+		if eConf.JWT == nil && (backend.HeaderRules.usesJWTClaims() || backend.QueryRules.usesJWTClaims()) {
+			return errors.Errorf("jwt claims in headers or query rules require jwt verification for endpoint '%s'", eConf.Endpoint)
+		}
+		// 의존관계는 이전 Backend만 지정 가능 (순환 방지)
+		for _, dep := range backend.DependsOn {
+			if dep < 0 || dep >= bIdx {
+				return &InvalidDependencyError{Endpoint: eConf.Endpoint, Method: eConf.Method, Backend: bIdx, Dependency: dep}
+			}
+		}
 	}
 
 	return nil
@@ -797,23 +1234,319 @@ func (bConf *BackendConfig) InitializeDefaults() error {
 	return nil
 }
 
+// IsMock - Host 대신 Mock 설정의 고정 응답을 사용하는 Backend 인지 여부
+func (bConf *BackendConfig) IsMock() bool {
+	return bConf.Mock != nil && len(bConf.Hosts) == 0
+}
+
 // Validate - 설정 검증
 func (bConf *BackendConfig) Validate() error {
-	if len(bConf.Hosts) == 0 {
-		return ErrNoHosts
-	}
+	if bConf.IsMock() {
+		if err := bConf.Mock.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid mock for backend '%s'", bConf.URLPattern)
+		}
+	} else {
+		if len(bConf.Hosts) == 0 {
+			return ErrNoHosts
+		}
 
-	if bConf.URLPattern == "" {
-		return errors.New("invalid backend url pattern")
+		if bConf.URLPattern == "" {
+			return errors.New("invalid backend url pattern")
+		}
 	}
 
 	if !core.ContainsString(encodings, bConf.Encoding) {
 		return errors.New("invalid encoding for backend")
 	}
 
+	if bConf.Mirror != nil {
+		if len(bConf.Mirror.Hosts) == 0 {
+			return errors.Errorf("mirror hosts required for backend '%s'", bConf.URLPattern)
+		}
+		if bConf.Mirror.MaxConcurrency < 0 {
+			return errors.Errorf("mirror max_concurrency must not be negative for backend '%s'", bConf.URLPattern)
+		}
+	}
+
+	if err := bConf.Retry.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid retry for backend '%s'", bConf.URLPattern)
+	}
+
+	if err := bConf.CircuitBreaker.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid circuit_breaker for backend '%s'", bConf.URLPattern)
+	}
+
+	if bConf.Hedge != nil {
+		if !strings.EqualFold(bConf.Method, http.MethodGet) || len(bConf.Hosts) < 2 {
+			return errors.Errorf("hedge requires GET method and multiple hosts for backend '%s'", bConf.URLPattern)
+		}
+		if err := bConf.Hedge.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid hedge for backend '%s'", bConf.URLPattern)
+		}
+	}
+
+	if err := validateOps(bConf.Middleware, "flatmap_filter", FlatmapOps); err != nil {
+		return errors.Wrapf(err, "invalid flatmap_filter for backend '%s'", bConf.URLPattern)
+	}
+
+	for name, rules := range map[string]*RequestRulesConfig{"headers": bConf.HeaderRules, "query": bConf.QueryRules} {
+		if err := rules.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid %s rules for backend '%s'", name, bConf.URLPattern)
+		}
+	}
+
+	if err := validateSuccessCodes(bConf.Middleware); err != nil {
+		return errors.Wrapf(err, "invalid success_codes for backend '%s'", bConf.URLPattern)
+	}
+
+	if err := validateBulkhead(bConf.Middleware); err != nil {
+		return errors.Wrapf(err, "invalid mw-bulkhead for backend '%s'", bConf.URLPattern)
+	}
+
+	if err := validateOps(bConf.Middleware, "request_transform", RequestTransformOps); err != nil {
+		return errors.Wrapf(err, "invalid request_transform for backend '%s'", bConf.URLPattern)
+	}
+
+	if err := validateQuery(bConf.Middleware); err != nil {
+		return errors.Wrapf(err, "invalid query for backend '%s'", bConf.URLPattern)
+	}
+
+	if conflicts := bConf.queryConflicts(); len(conflicts) > 0 {
+		return errors.Errorf("query can not be used with %s for backend '%s'", strings.Join(conflicts, ", "), bConf.URLPattern)
+	}
+
 	return nil
 }
 
+// queryConflicts - query 가 지정된 경우에 적용되지 않는 응답 처리 설정 (whitelist, blacklist, mapping, flatmap_filter) 리스트 반환
+func (bConf *BackendConfig) queryConflicts() []string {
+	e, ok := toStringMap(bConf.Middleware["mw-proxy"])
+	if !ok {
+		return nil
+	}
+	if _, ok := e["query"]; !ok {
+		return nil
+	}
+
+	conflicts := []string{}
+	if len(bConf.Whitelist) > 0 {
+		conflicts = append(conflicts, "whitelist")
+	}
+	if len(bConf.Blacklist) > 0 {
+		conflicts = append(conflicts, "blacklist")
+	}
+	if len(bConf.Mapping) > 0 {
+		conflicts = append(conflicts, "mapping")
+	}
+	if _, ok := e["flatmap_filter"]; ok {
+		conflicts = append(conflicts, "flatmap_filter")
+	}
+	return conflicts
+}
+
+// validateSplit - 트래픽 분할 방식, Key, Variant 별 설정 (이름, 가중치, 값, Backend) 검증
+func (eConf *EndpointConfig) validateSplit() error {
+	sc := eConf.Split
+	switch sc.By {
+	case "", "percentage":
+	case "match", "hash":
+		if sc.Key != "ip" && !strings.HasPrefix(sc.Key, "header:") && !strings.HasPrefix(sc.Key, "cookie:") {
+			return errors.Errorf("key must be 'header:<name>', 'cookie:<name>' or 'ip' for '%s' split", sc.By)
+		}
+	default:
+		return errors.Errorf("unknown split method '%s'", sc.By)
+	}
+	if len(sc.Variants) == 0 {
+		return errors.New("no variants defined")
+	}
+
+	names := map[string]bool{}
+	total := 0
+	for i, v := range sc.Variants {
+		if v == nil || v.Name == "" {
+			return errors.Errorf("variants[%d] has no name", i)
+		}
+		if v.Name == "default" {
+			return errors.New("variant name 'default' is reserved for the endpoint backends")
+		}
+		if names[v.Name] {
+			return errors.Errorf("duplicated variant name '%s'", v.Name)
+		}
+		names[v.Name] = true
+
+		if sc.By == "match" {
+			if len(v.Values) == 0 {
+				return errors.Errorf("variant '%s' requires values for match split", v.Name)
+			}
+		} else if v.Weight < 0 || v.Weight > 100 {
+			return errors.Errorf("variant '%s' has invalid weight %d", v.Name, v.Weight)
+		}
+		total += v.Weight
+
+		if err := eConf.VariantEndpoint(v).Validate(); err != nil {
+			return errors.Wrapf(err, "invalid variant '%s'", v.Name)
+		}
+	}
+	if sc.By != "match" && total > 100 {
+		return errors.Errorf("sum of variant weights %d exceeds 100", total)
+	}
+	return nil
+}
+
+// Validate - Retry 설정 검증 (호출 횟수, 대기 시간, 상태 코드)
+func (rc *RetryConfig) Validate() error {
+	if rc == nil {
+		return nil
+	}
+	if rc.MaxAttempts < 1 {
+		return errors.Errorf("max_attempts must be greater than 0, but %d", rc.MaxAttempts)
+	}
+	if rc.Backoff < 0 || rc.MaxBackoff < 0 {
+		return errors.New("backoff must not be negative")
+	}
+	for _, code := range rc.StatusCodes {
+		if http.StatusText(code) == "" {
+			return errors.Errorf("invalid status code %d", code)
+		}
+	}
+	return nil
+}
+
+// Validate - Circuit Breaker 설정 검증 (Open 조건, Cool-down, 시험 호출 수)
+func (cb *CircuitBreakerConfig) Validate() error {
+	if cb == nil {
+		return nil
+	}
+	if cb.ConsecutiveFailures < 0 {
+		return errors.Errorf("consecutive_failures must not be negative, but %d", cb.ConsecutiveFailures)
+	}
+	if cb.FailureRatio < 0 || cb.FailureRatio > 1 {
+		return errors.Errorf("failure_ratio must be between 0 and 1, but %v", cb.FailureRatio)
+	}
+	if cb.ConsecutiveFailures == 0 && cb.FailureRatio == 0 {
+		return errors.New("consecutive_failures or failure_ratio required")
+	}
+	if cb.FailureRatio > 0 && cb.Interval <= 0 {
+		return errors.New("interval must be greater than 0 for failure_ratio")
+	}
+	if cb.CoolDown <= 0 {
+		return errors.New("cool_down must be greater than 0")
+	}
+	if cb.HalfOpenRequests < 1 {
+		return errors.Errorf("half_open_requests must be greater than 0, but %d", cb.HalfOpenRequests)
+	}
+	return nil
+}
+
+// Validate - Hedge 설정 검증 (대기 시간, Percentile, 추가 요청 비율)
+func (hc *HedgeConfig) Validate() error {
+	if hc.Delay < 0 {
+		return errors.New("delay must not be negative")
+	}
+	if hc.Percentile < 0 || hc.Percentile >= 100 {
+		return errors.Errorf("percentile must be between 0 and 100, but %v", hc.Percentile)
+	}
+	if hc.Delay == 0 && hc.Percentile == 0 {
+		return errors.New("delay or percentile required")
+	}
+	if hc.MaxRatio <= 0 || hc.MaxRatio > 1 {
+		return errors.Errorf("max_ratio must be greater than 0 and less than or equal to 1, but %v", hc.MaxRatio)
+	}
+	return nil
+}
+
+// Validate - Mock 설정 검증 (상태 코드, Body와 File 중복 지정, File 존재 여부)
+func (mc *MockConfig) Validate() error {
+	if http.StatusText(mc.StatusCode) == "" {
+		return errors.Errorf("invalid status code %d", mc.StatusCode)
+	}
+	if mc.File != "" {
+		if mc.Body != nil {
+			return errors.New("body and file can not be used together")
+		}
+		if _, err := os.Stat(mc.File); err != nil {
+			return errors.Wrapf(err, "can not access the file '%s'", mc.File)
+		}
+	}
+	return nil
+}
+
+// Validate - 설정 검증
+func (rr *RequestRulesConfig) Validate() error {
+	if rr == nil {
+		return nil
+	}
+	for k, v := range rr.Rename {
+		if k == "" || v == "" {
+			return errors.Errorf("rename requires non-empty names: '%s' -> '%s'", k, v)
+		}
+	}
+	for _, k := range rr.Remove {
+		if k == "" {
+			return errors.New("remove requires non-empty names")
+		}
+	}
+	for _, m := range []map[string]string{rr.Set, rr.Add} {
+		for k := range m {
+			if k == "" {
+				return errors.New("set and add require non-empty names")
+			}
+		}
+	}
+	return nil
+}
+
+// usesJWTClaims - 설정 (set, add) 값에 JWT Claim 변수 ("{jwt.<claim>}") 가 사용되었는지 여부
+func (rr *RequestRulesConfig) usesJWTClaims() bool {
+	if rr == nil {
+		return false
+	}
+	for _, m := range []map[string]string{rr.Set, rr.Add} {
+		for _, v := range m {
+			if strings.Contains(v, "{jwt.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate - JWT 검증 설정 검증 (서명 알고리즘과 키, 토큰 추출 위치, 허용 오차)
+func (jc *JWTConfig) Validate() error {
+	if jc == nil {
+		return nil
+	}
+	if len(jc.SigningMethods) == 0 {
+		return errors.New("signing_methods required")
+	}
+	for i, sm := range jc.SigningMethods {
+		if sm == nil || !core.ContainsString(jwtAlgorithms, sm.Alg) {
+			return errors.Errorf("invalid signing_methods[%d] algorithm", i)
+		}
+		if sm.Key == "" {
+			return errors.Errorf("signing_methods[%d] key required", i)
+		}
+		if strings.HasPrefix(sm.Alg, "RS") {
+			if block, _ := pem.Decode([]byte(sm.Key)); block == nil || block.Type != "PUBLIC KEY" {
+				return errors.Errorf("signing_methods[%d] key must be a PEM-encoded public key", i)
+			}
+		}
+	}
+	parts := strings.SplitN(jc.TokenLookup, ":", 2)
+	if len(parts) != 2 || parts[1] == "" || !core.ContainsString(jwtTokenSources, parts[0]) {
+		return errors.Errorf("invalid token_lookup '%s'", jc.TokenLookup)
+	}
+	if jc.Leeway < 0 {
+		return errors.New("leeway must not be negative")
+	}
+	return nil
+}
+
+// MinArgs - 지정한 처리 유형의 최소 인자 개수 반환 (알 수 없는 처리 유형은 0)
+func (oa OpArgs) MinArgs(opType string) int {
+	return oa[opType][0]
+}
+
 // Error - 비 호환 버전에 대한 오류 문자열 반환
 func (u *UnsupportedVersionError) Error() string {
 	return fmt.Sprintf("Unsupported version: %d (wanted: %d)", u.Have, u.Want)
@@ -855,6 +1588,234 @@ func fromSetToSortedSlice(set map[string]interface{}) []string {
 	return res
 }
 
+// toParamTemplate - 지정한 템플릿에 존재하는 Input 파라미터와 "{respN_xxx}" 파라미터를 "{{.Xxx}}" 형식으로 변경 (그 외는 유지)
+func toParamTemplate(tmpl string, inputParams map[string]interface{}) string {
+	for _, output := range core.ExtractPlaceHoldersFromURLTemplate(tmpl, core.SimpleURLKeysPattern) {
+		if _, ok := inputParams[output]; !ok && !core.SequentialParamsPattern.MatchString(output) {
+			continue
+		}
+		tmpl = strings.Replace(tmpl, "{"+output+"}", "{{."+strings.Title(output)+"}}", -1)
+	}
+	return tmpl
+}
+
+// toStringMap - 지정한 Middleware 설정 값을 문자열 키 기반의 맵으로 변환
+func toStringMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case MWConfig:
+		return m, true
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			res[fmt.Sprintf("%v", k)] = e
+		}
+		return res, true
+	}
+	return nil, false
+}
+
+// validateFanout - 지정한 순서의 Backend Middleware 설정 ("mw-proxy") 의 fanout 설정 (Source Backend 순서, 경로, 동시 호출 수, Timeout, 실패 처리 방식) 과
+// Endpoint Middleware 설정의 Merging 방식 (sequential / dag) 및 Combiner 검증
+func validateFanout(bIdx int, mw MWConfig, endpointMW MWConfig) error {
+	e, ok := toStringMap(mw["mw-proxy"])
+	if !ok {
+		return nil
+	}
+	f, ok := e["fanout"]
+	if !ok {
+		return nil
+	}
+	fc, ok := toStringMap(f)
+	if !ok {
+		return errors.New("fanout must be a map")
+	}
+
+	// 이전 응답이 필요하므로 병렬 Merging은 사용할 수 없고, 원본 항목을 대체하므로 컬랙션을 연결하는 Combiner는 사용할 수 없음
+	ec, _ := toStringMap(endpointMW["mw-proxy"])
+	if sequential, _ := ec["sequential"].(bool); !sequential {
+		if dag, _ := ec["dag"].(bool); !dag {
+			return errors.New("fanout requires sequential or dag merging for endpoint")
+		}
+	}
+	if ec["combiner"] == "concat_collection" {
+		return errors.New("fanout can not be used with concat_collection combiner")
+	}
+
+	// 항목은 이전 Backend 응답에서만 제공 가능 (미 지정시 0)
+	source := 0
+	if v, ok := fc["source"]; ok {
+		if source, ok = v.(int); !ok {
+			return errors.Errorf("source '%v' must be a backend index", v)
+		}
+	}
+	if source < 0 || source >= bIdx {
+		return errors.Errorf("source %d must be an earlier backend index", source)
+	}
+	for _, k := range []string{"path", "target"} {
+		if v, ok := fc[k]; ok {
+			if _, ok := v.(string); !ok {
+				return errors.Errorf("%s '%v' must be a string", k, v)
+			}
+		}
+	}
+	if v, ok := fc["concurrency"]; ok {
+		if n, ok := v.(int); !ok || n < 0 {
+			return errors.Errorf("concurrency '%v' must not be negative", v)
+		}
+	}
+	if v, ok := fc["item_timeout"]; ok && !isNonNegativeDuration(v) {
+		return errors.Errorf("item_timeout '%v' must be a non-negative duration like '2s'", v)
+	}
+	if v, ok := fc["failure"]; ok {
+		if failure, ok := v.(string); !ok || !core.ContainsString(fanoutFailures, failure) {
+			return errors.Errorf("failure '%v' must be one of %v", v, fanoutFailures)
+		}
+	}
+	return nil
+}
+
+// isNonNegativeDuration - 지정한 설정 값이 0 이상의 시간 (정수 또는 "2s" 형식의 문자열) 인지 검증
+func isNonNegativeDuration(v interface{}) bool {
+	switch t := v.(type) {
+	case int:
+		return t >= 0
+	case string:
+		d, err := time.ParseDuration(t)
+		return err == nil && d >= 0
+	}
+	return false
+}
+
+// validateBulkhead - Middleware 설정 ("mw-bulkhead") 의 동시 처리 요청 수 (max_concurrent), 대기 요청 수 (max_queue), 대기 시간 (queue_timeout) 검증
+func validateBulkhead(mw MWConfig) error {
+	v, ok := mw["mw-bulkhead"]
+	if !ok {
+		return nil
+	}
+	bc, ok := toStringMap(v)
+	if !ok {
+		return errors.New("mw-bulkhead must be a map")
+	}
+	for _, k := range []string{"max_concurrent", "max_queue"} {
+		if v, ok := bc[k]; ok {
+			if n, ok := v.(int); !ok || n < 0 {
+				return errors.Errorf("%s '%v' must not be negative", k, v)
+			}
+		}
+	}
+	if v, ok := bc["queue_timeout"]; ok && !isNonNegativeDuration(v) {
+		return errors.Errorf("queue_timeout '%v' must be a non-negative duration like '500ms'", v)
+	}
+	return nil
+}
+
+// validateOps - Backend Middleware 설정 ("mw-proxy") 의 지정한 Flatmap 기반 변환 설정 (flatmap_filter, request_transform) 의 처리 유형과 인자 검증
+func validateOps(mw MWConfig, key string, opArgs OpArgs) error {
+	e, ok := toStringMap(mw["mw-proxy"])
+	if !ok {
+		return nil
+	}
+	f, ok := e[key]
+	if !ok {
+		return nil
+	}
+	ops, ok := f.([]interface{})
+	if !ok {
+		return errors.Errorf("%s must be a list of operations", key)
+	}
+
+	for i, v := range ops {
+		op, ok := toStringMap(v)
+		if !ok {
+			return errors.Errorf("operation[%d] must be a map with 'type' and 'args'", i)
+		}
+		opType, ok := op["type"].(string)
+		if !ok {
+			return errors.Errorf("operation[%d] has no type", i)
+		}
+		limits, ok := opArgs[opType]
+		if !ok {
+			return errors.Errorf("operation[%d] has unknown type '%s'", i, opType)
+		}
+
+		args, _ := op["args"].([]interface{})
+		if len(args) < limits[0] || (limits[1] >= 0 && len(args) > limits[1]) {
+			return errors.Errorf("operation[%d] '%s' has %d args (min: %d, max: %d)", i, opType, len(args), limits[0], limits[1])
+		}
+		strArgs := make([]string, len(args))
+		for j, arg := range args {
+			if strArgs[j], ok = arg.(string); !ok || strArgs[j] == "" {
+				return errors.Errorf("operation[%d] '%s' args[%d] must be a non-empty path", i, opType, j)
+			}
+		}
+
+		switch opType {
+		case "set":
+			if _, ok := op["value"]; !ok {
+				return errors.Errorf("operation[%d] 'set' requires a value", i)
+			}
+		case "template":
+			if _, ok := op["value"].(string); !ok {
+				return errors.Errorf("operation[%d] 'template' requires a string value", i)
+			}
+		case "rename":
+			if strings.ContainsAny(strArgs[1], ".*") {
+				return errors.Errorf("operation[%d] 'rename' target must be a single name: '%s'", i, strArgs[1])
+			}
+		case "cast":
+			if !core.ContainsString(flatmapCastTypes, strArgs[1]) {
+				return errors.Errorf("operation[%d] 'cast' has unknown type '%s' (allowed: %v)", i, strArgs[1], flatmapCastTypes)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSuccessCodes - Backend Middleware 설정 ("mw-http") 의 success_codes (상태 코드 또는 "2xx" 형식의 범위) 검증
+func validateSuccessCodes(mw MWConfig) error {
+	e, ok := toStringMap(mw["mw-http"])
+	if !ok {
+		return nil
+	}
+	v, ok := e["success_codes"]
+	if !ok {
+		return nil
+	}
+	codes, ok := v.([]interface{})
+	if !ok {
+		return errors.New("success_codes must be a list of status codes")
+	}
+	for i, c := range codes {
+		code := strings.ToLower(fmt.Sprintf("%v", c))
+		if !reStatusCode.MatchString(code) {
+			return errors.Errorf("success_codes[%d] '%v' must be a status code or a class like '2xx'", i, c)
+		}
+	}
+	return nil
+}
+
+// validateQuery - Middleware 설정 ("mw-proxy") 의 query (JMESPath 표현식) 검증
+func validateQuery(mw MWConfig) error {
+	e, ok := toStringMap(mw["mw-proxy"])
+	if !ok {
+		return nil
+	}
+	q, ok := e["query"]
+	if !ok {
+		return nil
+	}
+	expr, ok := q.(string)
+	if !ok || expr == "" {
+		return errors.New("query must be a non-empty JMESPath expression")
+	}
+	if _, err := jmespath.Compile(expr); err != nil {
+		return errors.Wrapf(err, "can not compile '%s'", expr)
+	}
+	return nil
+}
+
 // cleanHosts - Endpoint 및 Backend 설정에서 HostConfig 정보의 Host를 조정
 func cleanHosts(hcs []*HostConfig) {
 	for _, hc := range hcs {
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"net/http"
+	"testing"
+)
+
+func newValidEndpoint(backends ...*BackendConfig) *EndpointConfig {
+	for _, b := range backends {
+		if b.Hosts == nil {
+			b.Hosts = []*HostConfig{{Host: "http://backend"}}
+		}
+		if b.URLPattern == "" {
+			b.URLPattern = "/backend"
+		}
+		if b.Encoding == "" {
+			b.Encoding = "json"
+		}
+	}
+	return &EndpointConfig{Name: "test", Endpoint: "/test", Method: http.MethodGet, OutputEncoding: "json", Backend: backends}
+}
+
+func TestEndpointValidate_jwt(t *testing.T) {
+	hs256 := []*JWTSigningMethodConfig{{Alg: "HS256", Key: "secret"}}
+	claimRules := &RequestRulesConfig{Set: map[string]string{"X-User": "{jwt.sub}"}}
+
+	for _, tc := range []struct {
+		name  string
+		jwt   *JWTConfig
+		rules *RequestRulesConfig
+		valid bool
+	}{
+		{name: "claims without jwt", rules: claimRules},
+		{name: "claims with jwt", jwt: &JWTConfig{SigningMethods: hs256, TokenLookup: "header:Authorization"}, rules: claimRules, valid: true},
+		{name: "rules without claims", rules: &RequestRulesConfig{Set: map[string]string{"X-Id": "{request_id}"}}, valid: true},
+		{name: "without signing methods", jwt: &JWTConfig{TokenLookup: "header:Authorization"}, rules: claimRules},
+		{name: "unsupported algorithm", jwt: &JWTConfig{SigningMethods: []*JWTSigningMethodConfig{{Alg: "none", Key: "secret"}}, TokenLookup: "header:Authorization"}, rules: claimRules},
+		{name: "rsa key not pem", jwt: &JWTConfig{SigningMethods: []*JWTSigningMethodConfig{{Alg: "RS256", Key: "secret"}}, TokenLookup: "header:Authorization"}, rules: claimRules},
+		{name: "invalid token_lookup", jwt: &JWTConfig{SigningMethods: hs256, TokenLookup: "body:token"}, rules: claimRules},
+		{name: "negative leeway", jwt: &JWTConfig{SigningMethods: hs256, TokenLookup: "header:Authorization", Leeway: -1}, rules: claimRules},
+	} {
+		eConf := newValidEndpoint(&BackendConfig{HeaderRules: tc.rules})
+		eConf.JWT = tc.jwt
+		if err := eConf.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: unexpected validation result: %v", tc.name, err)
+		}
+	}
+}
+
+func TestEndpointValidate_fanout(t *testing.T) {
+	fanout := MWConfig{"mw-proxy": map[string]interface{}{"fanout": map[string]interface{}{"source": 0, "target": "detail"}}}
+
+	for _, tc := range []struct {
+		name  string
+		proxy map[string]interface{}
+		valid bool
+	}{
+		{name: "parallel merging"},
+		{name: "sequential merging", proxy: map[string]interface{}{"sequential": true}, valid: true},
+		{name: "dag merging", proxy: map[string]interface{}{"dag": true, "combiner": "deep_merge"}, valid: true},
+		{name: "concat_collection combiner", proxy: map[string]interface{}{"dag": true, "combiner": "concat_collection"}},
+	} {
+		eConf := newValidEndpoint(&BackendConfig{}, &BackendConfig{Middleware: fanout})
+		if tc.proxy != nil {
+			eConf.Middleware = MWConfig{"mw-proxy": tc.proxy}
+		}
+		if err := eConf.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: unexpected validation result: %v", tc.name, err)
+		}
+	}
+}
+
+func TestEndpointValidate_requestTransform(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		ops   interface{}
+		valid bool
+	}{
+		{name: "valid", ops: []interface{}{
+			map[string]interface{}{"type": "whitelist", "args": []interface{}{"name", "spec.cpu"}},
+			map[string]interface{}{"type": "rename", "args": []interface{}{"spec.cpu", "vCPU"}},
+			map[string]interface{}{"type": "set", "args": []interface{}{"spec.provider"}, "value": "aws"},
+			map[string]interface{}{"type": "template", "args": []interface{}{"nsId"}, "value": "{ns}"},
+		}, valid: true},
+		{name: "not a list", ops: "whitelist"},
+		{name: "unknown type", ops: []interface{}{map[string]interface{}{"type": "cast", "args": []interface{}{"a", "int"}}}},
+		{name: "too many args", ops: []interface{}{map[string]interface{}{"type": "move", "args": []interface{}{"a", "b", "c"}}}},
+		{name: "empty path", ops: []interface{}{map[string]interface{}{"type": "del", "args": []interface{}{""}}}},
+		{name: "set without value", ops: []interface{}{map[string]interface{}{"type": "set", "args": []interface{}{"a"}}}},
+		{name: "template not string", ops: []interface{}{map[string]interface{}{"type": "template", "args": []interface{}{"a"}, "value": 1}}},
+		{name: "rename to path", ops: []interface{}{map[string]interface{}{"type": "rename", "args": []interface{}{"a", "b.c"}}}},
+	} {
+		eConf := newValidEndpoint(&BackendConfig{Middleware: MWConfig{"mw-proxy": map[string]interface{}{"request_transform": tc.ops}}})
+		if err := eConf.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: unexpected validation result: %v", tc.name, err)
+		}
+	}
+}
+
+func TestEndpointValidate_bulkhead(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		conf  interface{}
+		valid bool
+	}{
+		{name: "valid", conf: map[string]interface{}{"max_concurrent": 100, "max_queue": 50, "queue_timeout": "500ms"}, valid: true},
+		{name: "unlimited", conf: map[string]interface{}{"max_concurrent": 0}, valid: true},
+		{name: "nanoseconds timeout", conf: map[string]interface{}{"max_concurrent": 1, "queue_timeout": 1000}, valid: true},
+		{name: "not a map", conf: "100"},
+		{name: "negative max_concurrent", conf: map[string]interface{}{"max_concurrent": -1}},
+		{name: "string max_concurrent", conf: map[string]interface{}{"max_concurrent": "100"}},
+		{name: "negative max_queue", conf: map[string]interface{}{"max_concurrent": 1, "max_queue": -1}},
+		{name: "invalid queue_timeout", conf: map[string]interface{}{"max_concurrent": 1, "queue_timeout": "soon"}},
+		{name: "negative queue_timeout", conf: map[string]interface{}{"max_concurrent": 1, "queue_timeout": "-1s"}},
+	} {
+		// Endpoint와 Backend 설정 모두 검증
+		eConf := newValidEndpoint(&BackendConfig{})
+		eConf.Middleware = MWConfig{"mw-bulkhead": tc.conf}
+		if err := eConf.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: unexpected endpoint validation result: %v", tc.name, err)
+		}
+
+		eConf = newValidEndpoint(&BackendConfig{Middleware: MWConfig{"mw-bulkhead": tc.conf}})
+		if err := eConf.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: unexpected backend validation result: %v", tc.name, err)
+		}
+	}
+}
@@ -78,6 +78,19 @@ func (n *node) Del(path ...string) {
 		return
 	}
 
+	if path[0] == deepWildcard {
+		// "**" 이후 Path를 현재 Node와 모든 하위 Node에 적용
+		if lenKs == 1 {
+			n.Del(wildcard)
+			return
+		}
+		n.Del(path[1:]...)
+		for _, e := range n.edges {
+			e.n.Del(path...)
+		}
+		return
+	}
+
 	if path[0] == wildcard {
 		if lenKs > 1 {
 			for _, e := range n.edges {
@@ -93,18 +106,15 @@ func (n *node) Del(path ...string) {
 		return
 	}
 
-	for i, e := range n.edges {
-		if e.label == path[0] {
-			if lenKs == 1 {
-				copy(n.edges[i:], n.edges[i+1:])
-				n.edges[len(n.edges)-1] = nil
-				n.edges = n.edges[:len(n.edges)-1]
-				return
-			}
-			e.n.Del(path[1:]...)
-			return
-		}
+	i := n.indexOf(path[0])
+	if i < 0 {
+		return
 	}
+	if lenKs == 1 {
+		n.removeEdgeAt(i)
+		return
+	}
+	n.edges[i].n.Del(path[1:]...)
 }
 
 // Get - 지정된 Path에 해당하는 Node 정보 반환
@@ -120,6 +130,17 @@ func (n *node) Get(path ...string) interface{} {
 		return n.expand()
 	}
 
+	if path[0] == deepWildcard {
+		// 현재 Node와 모든 하위 Node 중에서 나머지 Path에 해당하는 값들을 수집
+		res := []interface{}{}
+		for _, nap := range n.collectDescendants(nil, nil) {
+			if v := nap.n.Get(path[1:]...); v != nil {
+				res = append(res, v)
+			}
+		}
+		return res
+	}
+
 	if path[0] == wildcard {
 		res := make([]interface{}, lenEdges)
 		for i, e := range n.edges {
@@ -128,10 +149,8 @@ func (n *node) Get(path ...string) interface{} {
 		return res
 	}
 
-	for _, e := range n.edges {
-		if e.label == path[0] {
-			return e.n.Get(path[1:]...)
-		}
+	if i := n.indexOf(path[0]); i >= 0 {
+		return n.edges[i].n.Get(path[1:]...)
 	}
 	return nil
 }
@@ -164,6 +183,160 @@ func (n *node) expand() interface{} {
 	return res
 }
 
+// replace - 해당 Node의 하위 정보를 모두 제거하고 지정된 값으로 재 구성
+func (n *node) replace(v interface{}) {
+	for i := range n.edges {
+		n.edges[i] = nil
+	}
+	n.edges = n.edges[:0]
+	n.Value = nil
+	n.flatten(v)
+}
+
+// indexOf - 지정된 Path 단계에 해당하는 Edge의 위치 반환 (Collection Node는 배열 Index 사용, 음수는 마지막부터의 위치, 없는 경우는 -1)
+func (n *node) indexOf(step string) int {
+	if n.isCollection {
+		if idx, err := strconv.Atoi(step); err == nil {
+			if idx < 0 {
+				idx += len(n.edges)
+			}
+			if idx < 0 || idx >= len(n.edges) {
+				return -1
+			}
+			return idx
+		}
+	}
+	for i, e := range n.edges {
+		if e.label == step {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchEdges - 지정된 Path 단계 (Label, Wildcard, 배열 Index)에 해당하는 Edge 목록 반환
+func (n *node) matchEdges(step string) []*edge {
+	if step == wildcard {
+		return append([]*edge{}, n.edges...)
+	}
+	if i := n.indexOf(step); i >= 0 {
+		return []*edge{n.edges[i]}
+	}
+	return nil
+}
+
+// removeEdgeAt - 지정된 위치의 Edge 삭제
+func (n *node) removeEdgeAt(i int) {
+	copy(n.edges[i:], n.edges[i+1:])
+	n.edges[len(n.edges)-1] = nil
+	n.edges = n.edges[:len(n.edges)-1]
+}
+
+// setEmpty - 유지 대상이 없어서 하위 Node가 모두 삭제된 경우 Node의 값을 빈 객체 또는 빈 배열로 설정 (Keep 처리용, Del 처리는 기존과 같이 설정하지 않음)
+func (n *node) setEmpty() {
+	if len(n.edges) > 0 {
+		return
+	}
+	if n.isCollection {
+		n.Value = []interface{}{}
+	} else {
+		n.Value = map[string]interface{}{}
+	}
+}
+
+// removeEdge - 지정된 Edge 삭제
+func (n *node) removeEdge(e *edge) {
+	for i, c := range n.edges {
+		if c == e {
+			n.removeEdgeAt(i)
+			return
+		}
+	}
+}
+
+// collectDescendants - 해당 Node와 모든 하위 Node들을 지정된 Path 기준의 nodeAndPath 정보로 추가하여 반환
+func (n *node) collectDescendants(p []string, acc []nodeAndPath) []nodeAndPath {
+	acc = append(acc, nodeAndPath{n: n, p: p})
+	for _, e := range n.edges {
+		acc = e.n.collectDescendants(appendPath(p, e.label), acc)
+	}
+	return acc
+}
+
+// isObject - 하위 Node를 필드로 추가할 수 있는 객체 Node 인지 여부 (배열과 단일 값 Node는 제외)
+func (n *node) isObject() bool {
+	if n.isCollection {
+		return false
+	}
+	if !n.IsLeaf() {
+		return true
+	}
+	switch n.Value.(type) {
+	case nil, map[string]interface{}:
+		return true
+	}
+	return false
+}
+
+// setChild - 지정된 Label의 하위 Node를 지정된 값으로 교체 (객체 Node에 없는 경우는 추가, replaceOnly 지정시는 기존 Node만 교체)
+func (n *node) setChild(label string, v interface{}, replaceOnly bool) {
+	if i := n.indexOf(label); i >= 0 {
+		n.edges[i].n.replace(v)
+		return
+	}
+	if replaceOnly || !n.isObject() {
+		return
+	}
+	child := newNode(n.depth + 1)
+	n.edges = append(n.edges, &edge{label: label, n: child})
+	child.flatten(v)
+}
+
+// create - 지정된 Path의 Node를 지정된 값으로 교체하고, 없는 중간 경로는 객체 Node로 생성 (배열의 없는 Index나 단일 값 Node 하위는 생성하지 않음)
+func (n *node) create(path []string, v interface{}) {
+	if len(path) == 1 {
+		n.setChild(path[0], v, false)
+		return
+	}
+	if i := n.indexOf(path[0]); i >= 0 {
+		n.edges[i].n.create(path[1:], v)
+		return
+	}
+	if !n.isObject() {
+		return
+	}
+	child := newNode(n.depth + 1)
+	n.edges = append(n.edges, &edge{label: path[0], n: child})
+	child.Add(path[1:], v)
+}
+
+// prune - 유지 대상 Node와 상위 경로를 제외한 하위 Node 삭제 (유지할 Node가 없는 경우는 false 반환)
+func (n *node) prune(keep map[*node]bool) bool {
+	if keep[n] {
+		return true
+	}
+
+	if n.IsLeaf() {
+		return false
+	}
+
+	edges := n.edges[:0]
+	for _, e := range n.edges {
+		if e.n.prune(keep) {
+			edges = append(edges, e)
+		}
+	}
+	for i := len(edges); i < len(n.edges); i++ {
+		n.edges[i] = nil
+	}
+	n.edges = edges
+	if len(edges) == 0 {
+		n.setEmpty()
+		return false
+	}
+	return true
+}
+
 // SetDepth - 해당 Node의 Depth를 지정된 값으로 설정
 func (n *node) SetDepth(d int) {
 	n.depth = d
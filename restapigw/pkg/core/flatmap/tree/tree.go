@@ -5,7 +5,8 @@ import "errors"
 // ===== [ Constants and Variables ] =====
 
 const (
-	wildcard = "*"
+	wildcard     = "*"
+	deepWildcard = "**"
 )
 
 var (
@@ -37,20 +38,14 @@ type Tree struct {
 func (t *Tree) collectMoveCandidates(srcPath []string, next []nodeAndPath) []nodeAndPath {
 	acc := []nodeAndPath{}
 	for _, step := range srcPath {
-		if step == wildcard {
-			for _, nap := range next {
-				for _, e := range nap.n.edges {
-					acc = append(acc, nodeAndPath{n: e.n, p: append(nap.p, e.label)})
-				}
+		for _, nap := range next {
+			if step == deepWildcard {
+				// 현재 Node와 모든 하위 Node
+				acc = nap.n.collectDescendants(nap.p, acc)
+				continue
 			}
-		} else {
-			for _, nap := range next {
-				for _, e := range nap.n.edges {
-					if step == e.label {
-						acc = append(acc, nodeAndPath{n: e.n, p: append(nap.p, e.label)})
-						break
-					}
-				}
+			for _, e := range nap.n.matchEdges(step) {
+				acc = append(acc, nodeAndPath{n: e.n, p: appendPath(nap.p, e.label)})
 			}
 		}
 		next, acc = acc, next[:0]
@@ -153,23 +148,14 @@ func (t *Tree) Move(srcPath, destPath []string) {
 	isEdgeRelabel := prefixLen == lenDst
 
 	for _, nap := range next {
-		for i, e := range nap.n.edges {
-			if e.label != srcPath[prefixLen-1] {
-				continue
-			}
-
+		for _, e := range nap.n.matchEdges(srcPath[prefixLen-1]) {
 			if isEdgeRelabel {
 				e.label = destPath[prefixLen-1]
-				break
+				continue
 			}
 
 			edgesToMove = append(edgesToMove, edgeToMove{nodeAndPath: nap, e: e})
-
-			copy(nap.n.edges[i:], nap.n.edges[i+1:])
-			nap.n.edges[len(nap.n.edges)-1] = nil
-			nap.n.edges = nap.n.edges[:len(nap.n.edges)-1]
-
-			break
+			nap.n.removeEdge(e)
 		}
 	}
 
@@ -185,8 +171,130 @@ func (t *Tree) Move(srcPath, destPath []string) {
 	t.embeddingEdges(edgesToMove, destPath[prefixLen-1:])
 }
 
+// resolve - 지정한 Path (Wildcard 포함)에 해당하는 모든 Node와 실제 Path 정보 반환
+func (t *Tree) resolve(path []string) []nodeAndPath {
+	return t.collectMoveCandidates(path, []nodeAndPath{{n: t.root, p: []string{}}})
+}
+
+// Set - 지정된 Path (Wildcard 포함)에 해당하는 Node를 지정된 값으로 교체 (객체에 없는 경우는 추가)
+// (배열의 없는 Index는 추가하지 않으며, 상위 경로에 "**" 가 사용된 경우는 존재하는 Node만 교체)
+func (t *Tree) Set(path []string, v interface{}) {
+	if v == nil {
+		return
+	}
+	lenPath := len(path)
+	if lenPath == 0 {
+		t.root.replace(v)
+		return
+	}
+
+	// Wildcard가 없는 경우는 없는 중간 경로를 생성하면서 설정
+	if !hasWildcard(path[:lenPath-1]) {
+		t.root.create(path, v)
+		return
+	}
+
+	replaceOnly := hasDeepWildcard(path[:lenPath-1])
+	for _, nap := range t.resolve(path[:lenPath-1]) {
+		nap.n.setChild(path[lenPath-1], v, replaceOnly)
+	}
+}
+
+// Copy - 지정된 source Path (Wildcard 포함)에 해당하는 모든 값들을 destination Path로 복사 (destination의 Wildcard는 source에서 일치한 값으로 대체)
+func (t *Tree) Copy(srcPath, destPath []string) {
+	type copyItem struct {
+		p []string
+		v interface{}
+	}
+
+	// 복사 대상 값들을 먼저 추출
+	items := []copyItem{}
+	for _, nap := range t.resolve(srcPath) {
+		items = append(items, copyItem{p: substitutePath(destPath, nap.p), v: nap.n.Get()})
+	}
+	for _, item := range items {
+		t.Set(item.p, item.v)
+	}
+}
+
+// Rename - 지정된 Path (Wildcard 포함)에 해당하는 모든 Edge의 이름을 지정된 이름으로 변경 (동일한 이름이 존재하면 교체)
+func (t *Tree) Rename(path []string, label string) {
+	lenPath := len(path)
+	if lenPath == 0 || path[lenPath-1] == label {
+		return
+	}
+
+	for _, nap := range t.resolve(path[:lenPath-1]) {
+		for _, e := range nap.n.matchEdges(path[lenPath-1]) {
+			nap.n.Del(label)
+			e.label = label
+		}
+	}
+}
+
+// Apply - 지정된 Path (Wildcard 포함)에 해당하는 모든 값을 지정된 함수의 처리 결과로 교체
+func (t *Tree) Apply(path []string, fn func(interface{}) interface{}) {
+	for _, nap := range t.resolve(path) {
+		if v := fn(nap.n.Get()); v != nil {
+			nap.n.replace(v)
+		}
+	}
+}
+
+// Keep - 지정된 Path (Wildcard 포함)들에 해당하는 Node들과 상위 경로만 유지하고 나머지 Node들은 모두 삭제
+func (t *Tree) Keep(paths ...[]string) {
+	keep := map[*node]bool{}
+	for _, path := range paths {
+		for _, nap := range t.resolve(path) {
+			keep[nap.n] = true
+		}
+	}
+	t.root.prune(keep)
+}
+
 // ===== [ Private Functions ] =====
 
+// appendPath - 지정된 Path에 Label을 추가한 새로운 Path 반환 (원본 Path의 공유 방지)
+func appendPath(path []string, label string) []string {
+	res := make([]string, len(path)+1)
+	copy(res, path)
+	res[len(path)] = label
+	return res
+}
+
+// hasWildcard - 지정된 Path에 Wildcard ("*", "**")가 존재하는지 검증
+func hasWildcard(path []string) bool {
+	for _, p := range path {
+		if p == wildcard || p == deepWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDeepWildcard - 지정된 Path에 "**" 가 존재하는지 검증
+func hasDeepWildcard(path []string) bool {
+	for _, p := range path {
+		if p == deepWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// substitutePath - 지정된 Path의 Wildcard를 실제 Path의 동일 위치 값으로 대체한 Path 반환
+func substitutePath(path, actual []string) []string {
+	res := make([]string, len(path))
+	for i, p := range path {
+		if p == wildcard && i < len(actual) {
+			res[i] = actual[i]
+		} else {
+			res[i] = p
+		}
+	}
+	return res
+}
+
 // ===== [ Public Functions ] =====
 
 // New - 지정된 정보를 기준으로 새로운 Tree 생성
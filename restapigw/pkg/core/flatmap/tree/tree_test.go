@@ -0,0 +1,243 @@
+package tree
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sample - 테스트 데이터 생성 (테스트 별로 변경되므로 매번 생성)
+func sample() map[string]interface{} {
+	return map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{"c": 1, "d": 2},
+			"e": 3,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": 1, "name": "x", "meta": map[string]interface{}{"id": 10}},
+			map[string]interface{}{"id": 2, "name": "y", "meta": map[string]interface{}{"id": 20}},
+			map[string]interface{}{"id": 3, "name": "z", "meta": map[string]interface{}{"id": 30}},
+		},
+		"f": "g",
+	}
+}
+
+func path(p string) []string {
+	if p == "" {
+		return []string{}
+	}
+	return strings.Split(p, ".")
+}
+
+func decode(t *testing.T, s string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid expected json %s: %s", s, err.Error())
+	}
+	return v
+}
+
+// assertTree - Tree 전체 결과를 JSON 기준으로 비교
+func assertTree(t *testing.T, name string, tr *Tree, expected string) {
+	t.Helper()
+	actual, _ := json.Marshal(tr.Get([]string{}))
+	var got interface{}
+	json.Unmarshal(actual, &got)
+	if !reflect.DeepEqual(got, decode(t, expected)) {
+		t.Errorf("%s: unexpected result\n\tactual:   %s\n\texpected: %s", name, actual, expected)
+	}
+}
+
+func newTree(t *testing.T) *Tree {
+	tr, err := New(sample())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
+
+func TestNew_nil(t *testing.T) {
+	if _, err := New(nil); err != errNoNilValuesAllowed {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTree_Get(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{"a.b.c", `1`},
+		{"a.b", `{"c": 1, "d": 2}`},
+		{"items.0.name", `"x"`},
+		{"items.-1.name", `"z"`},
+		{"items.-3.id", `1`},
+		{"items.3.id", `null`},
+		{"items.-4.id", `null`},
+		{"items.*.id", `[1, 2, 3]`},
+		{"**.id", `[1, 10, 2, 20, 3, 30]`},
+		{"a.**.c", `[1]`},
+		{"unknown", `null`},
+	} {
+		actual, _ := json.Marshal(newTree(t).Get(path(tc.path)))
+		var got interface{}
+		json.Unmarshal(actual, &got)
+		// "**" 결과는 순회 순서에 따르므로 항목 구성만 비교
+		if strings.HasPrefix(tc.path, "**") {
+			if arr, ok := got.([]interface{}); !ok || len(arr) != 6 {
+				t.Errorf("get %s: unexpected result %s", tc.path, actual)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, decode(t, tc.expected)) {
+			t.Errorf("get %s: unexpected result %s (expected: %s)", tc.path, actual, tc.expected)
+		}
+	}
+}
+
+func TestTree_Del(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		paths    []string
+		expected string
+	}{
+		{"leaf", []string{"a.b.c"}, `{"a": {"b": {"d": 2}, "e": 3}, "items": [{"id": 1, "name": "x", "meta": {"id": 10}}, {"id": 2, "name": "y", "meta": {"id": 20}}, {"id": 3, "name": "z", "meta": {"id": 30}}], "f": "g"}`},
+		{"branch", []string{"a", "items"}, `{"f": "g"}`},
+		{"unknown", []string{"a.x.y", "x"}, `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "items": [{"id": 1, "name": "x", "meta": {"id": 10}}, {"id": 2, "name": "y", "meta": {"id": 20}}, {"id": 3, "name": "z", "meta": {"id": 30}}], "f": "g"}`},
+		{"wildcard", []string{"items.*.meta", "items.*.name"}, `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "items": [{"id": 1}, {"id": 2}, {"id": 3}], "f": "g"}`},
+		{"index", []string{"items.0", "a"}, `{"items": [{"id": 2, "name": "y", "meta": {"id": 20}}, {"id": 3, "name": "z", "meta": {"id": 30}}], "f": "g"}`},
+		{"negative index", []string{"items.-1", "a"}, `{"items": [{"id": 1, "name": "x", "meta": {"id": 10}}, {"id": 2, "name": "y", "meta": {"id": 20}}], "f": "g"}`},
+		{"out of range index", []string{"items.3", "items.-4", "a"}, `{"items": [{"id": 1, "name": "x", "meta": {"id": 10}}, {"id": 2, "name": "y", "meta": {"id": 20}}, {"id": 3, "name": "z", "meta": {"id": 30}}], "f": "g"}`},
+		// 마지막 하위 Node 삭제시 기존과 동일하게 null 처리
+		{"deep wildcard", []string{"**.id", "a"}, `{"items": [{"name": "x", "meta": null}, {"name": "y", "meta": null}, {"name": "z", "meta": null}], "f": "g"}`},
+		{"last child", []string{"a.b.c", "a.b.d", "items"}, `{"a": {"b": null, "e": 3}, "f": "g"}`},
+		{"all children", []string{"a.b.*", "items"}, `{"a": {"b": null, "e": 3}, "f": "g"}`},
+	} {
+		tr := newTree(t)
+		for _, p := range tc.paths {
+			tr.Del(path(p))
+		}
+		assertTree(t, "del "+tc.name, tr, tc.expected)
+	}
+}
+
+func TestTree_Move(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		src, dst string
+		expected string
+	}{
+		{"relabel", "a.e", "a.x", `{"a": {"b": {"c": 1, "d": 2}, "x": 3}, "f": "g"}`},
+		{"promote", "a.b.c", "c", `{"a": {"b": {"d": 2}, "e": 3}, "c": 1, "f": "g"}`},
+		{"embed", "f", "h.i.f", `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "h": {"i": {"f": "g"}}}`},
+		{"wildcard relabel", "items.*.name", "items.*.title", `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "items": [{"id": 1, "title": "x", "meta": {"id": 10}}, {"id": 2, "title": "y", "meta": {"id": 20}}, {"id": 3, "title": "z", "meta": {"id": 30}}], "f": "g"}`},
+		{"index", "items.-1.name", "items.-1.title", `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "items": [{"id": 1, "name": "x", "meta": {"id": 10}}, {"id": 2, "name": "y", "meta": {"id": 20}}, {"id": 3, "title": "z", "meta": {"id": 30}}], "f": "g"}`},
+	} {
+		tr := newTree(t)
+		if tc.name != "wildcard relabel" && tc.name != "index" {
+			tr.Del(path("items"))
+		}
+		tr.Move(path(tc.src), path(tc.dst))
+		assertTree(t, "move "+tc.name, tr, tc.expected)
+	}
+}
+
+func TestTree_Set(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		path     string
+		value    interface{}
+		expected string
+	}{
+		{"replace", "a.b", "v", `{"a": {"b": "v", "e": 3}, "f": "g"}`},
+		{"add", "a.x", []interface{}{1, 2}, `{"a": {"b": {"c": 1, "d": 2}, "e": 3, "x": [1, 2]}, "f": "g"}`},
+		{"create parents", "x.y.z", true, `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "f": "g", "x": {"y": {"z": true}}}`},
+		{"root", "", map[string]interface{}{"k": "v"}, `{"k": "v"}`},
+		{"nil", "a", nil, `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "f": "g"}`},
+	} {
+		tr := newTree(t)
+		tr.Del(path("items"))
+		tr.Set(path(tc.path), tc.value)
+		assertTree(t, "set "+tc.name, tr, tc.expected)
+	}
+
+	// Wildcard와 배열 Index
+	tr := newTree(t)
+	tr.Del(path("a"))
+	tr.Set(path("items.*.meta"), "m")
+	tr.Set(path("items.-1.name"), "last")
+	tr.Set(path("items.5.name"), "ignored")
+	tr.Set(path("**.id"), 0)
+	assertTree(t, "set wildcard", tr, `{"items": [{"id": 0, "name": "x", "meta": "m"}, {"id": 0, "name": "y", "meta": "m"}, {"id": 0, "name": "last", "meta": "m"}], "f": "g"}`)
+}
+
+func TestTree_Copy(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		src, dst string
+		expected string
+	}{
+		{"value", "a.e", "x", `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "f": "g", "x": 3}`},
+		{"branch", "a.b", "a.copy", `{"a": {"b": {"c": 1, "d": 2}, "copy": {"c": 1, "d": 2}, "e": 3}, "f": "g"}`},
+		{"unknown", "a.x", "y", `{"a": {"b": {"c": 1, "d": 2}, "e": 3}, "f": "g"}`},
+	} {
+		tr := newTree(t)
+		tr.Del(path("items"))
+		tr.Copy(path(tc.src), path(tc.dst))
+		assertTree(t, "copy "+tc.name, tr, tc.expected)
+	}
+
+	// Wildcard는 원본 위치를 유지해서 복사
+	tr := newTree(t)
+	tr.Del(path("a"))
+	tr.Copy(path("items.*.meta.id"), path("items.*.metaId"))
+	tr.Del(path("items.*.meta"))
+	tr.Copy(path("items.-1.name"), path("lastName"))
+	assertTree(t, "copy wildcard", tr, `{"items": [{"id": 1, "name": "x", "metaId": 10}, {"id": 2, "name": "y", "metaId": 20}, {"id": 3, "name": "z", "metaId": 30}], "f": "g", "lastName": "z"}`)
+}
+
+func TestTree_Rename(t *testing.T) {
+	tr := newTree(t)
+	tr.Rename(path("a.e"), "x")
+	tr.Rename(path("items.*.name"), "title")
+	tr.Rename(path("items.0.id"), "key")
+	// 동일한 이름이 존재하는 경우는 교체
+	tr.Rename(path("a.x"), "b")
+	tr.Rename(path("f"), "f")
+	tr.Rename(path("unknown"), "u")
+	tr.Del(path("items.*.meta"))
+	assertTree(t, "rename", tr, `{"a": {"b": 3}, "items": [{"key": 1, "title": "x"}, {"id": 2, "title": "y"}, {"id": 3, "title": "z"}], "f": "g"}`)
+}
+
+func TestTree_Apply(t *testing.T) {
+	tr := newTree(t)
+	tr.Del(path("a"))
+	tr.Apply(path("items.*.id"), func(v interface{}) interface{} { return v.(int) * 100 })
+	tr.Apply(path("items.*.meta"), func(v interface{}) interface{} { return nil })
+	assertTree(t, "apply", tr, `{"items": [{"id": 100, "name": "x", "meta": {"id": 10}}, {"id": 200, "name": "y", "meta": {"id": 20}}, {"id": 300, "name": "z", "meta": {"id": 30}}], "f": "g"}`)
+}
+
+func TestTree_Keep(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		paths    []string
+		expected string
+	}{
+		{"paths", []string{"a.b.c", "f"}, `{"a": {"b": {"c": 1}}, "f": "g"}`},
+		{"branch", []string{"a.b"}, `{"a": {"b": {"c": 1, "d": 2}}}`},
+		{"wildcard", []string{"items.*.name"}, `{"items": [{"name": "x"}, {"name": "y"}, {"name": "z"}]}`},
+		{"index", []string{"items.0.id", "items.-1.id", "items.9.id"}, `{"items": [{"id": 1}, {"id": 3}]}`},
+		{"deep wildcard", []string{"**.meta.id"}, `{"items": [{"meta": {"id": 10}}, {"meta": {"id": 20}}, {"meta": {"id": 30}}]}`},
+		// 유지할 경로가 없는 경우는 빈 객체
+		{"nothing", []string{"x.y"}, `{}`},
+	} {
+		tr := newTree(t)
+		ps := make([][]string, len(tc.paths))
+		for i, p := range tc.paths {
+			ps[i] = path(p)
+		}
+		tr.Keep(ps...)
+		assertTree(t, "keep "+tc.name, tr, tc.expected)
+	}
+}
@@ -9,6 +9,8 @@ import (
 const (
 	// NOOP - 응답을 변환없이 반환하기 위한 식별자
 	NOOP = "no-op"
+	// TEMPLATE - 응답을 Endpoint에 지정한 Template으로 출력하기 위한 식별자 (output_encoding 전용)
+	TEMPLATE = "template"
 )
 
 var (
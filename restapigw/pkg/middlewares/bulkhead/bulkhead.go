@@ -0,0 +1,138 @@
+// Package bulkhead - 동시 처리 요청 수 제한 (Bulkhead) 처리를 지원하는 패키지
+package bulkhead
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// MWNamespace - Middleware configuration 식별자
+	MWNamespace = "mw-bulkhead"
+)
+
+var (
+	// ErrProxySaturated - Endpoint 동시 처리 제한을 초과한 경우의 오류
+	ErrProxySaturated = core.NewWrappedError(http.StatusServiceUnavailable, "Proxy(Endpoint) concurrency limit exceeded", errors.New("ERROR: Proxy(Endpoint) concurrency limit exceeded"))
+	// ErrBackendSaturated - Backend 동시 처리 제한을 초과한 경우의 오류
+	ErrBackendSaturated = core.NewWrappedError(http.StatusServiceUnavailable, "Proxy(Backend) concurrency limit exceeded", errors.New("ERROR: Proxy(Backend) concurrency limit exceeded"))
+)
+
+// ===== [ Types ] =====
+
+type (
+	// Config - Bulkhead 구성을 위한 Configuration 구조
+	Config struct {
+		MaxConcurrent int64         `yaml:"max_concurrent"` // 동시에 처리할 최대 요청 수
+		MaxQueue      int64         `yaml:"max_queue"`      // 처리 대기할 최대 요청 수 (0 이면 대기 없이 거부)
+		QueueTimeout  time.Duration `yaml:"queue_timeout"`  // 처리 대기할 최대 시간 (0 이면 요청 처리 기한까지 대기)
+	}
+
+	// Reporter - 처리 중/대기 중인 요청 수와 거부 여부를 수집하는 함수 형식 (Metrics 연계용)
+	Reporter func(layer, name string, inFlight, queued int64, rejected bool)
+
+	// Bulkhead - 동시 처리 요청 수와 대기 요청 수를 제한하는 구조
+	Bulkhead struct {
+		conf     *Config
+		slots    chan struct{}
+		queued   int64
+		layer    string
+		name     string
+		reporter Reporter
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// report - 현재 처리 중/대기 중인 요청 수를 Reporter로 전달
+func (b *Bulkhead) report(rejected bool) {
+	if b.reporter != nil {
+		b.reporter(b.layer, b.name, int64(len(b.slots)), atomic.LoadInt64(&b.queued), rejected)
+	}
+}
+
+// Acquire - 처리 가능한 경우 처리 슬롯을 할당 (처리 중인 요청이 최대인 경우는 대기열에서 대기하고, 대기열이 가득 찼거나 대기 시간 초과시 거부)
+func (b *Bulkhead) Acquire(ctx context.Context) bool {
+	select {
+	case b.slots <- struct{}{}:
+		b.report(false)
+		return true
+	default:
+	}
+
+	if atomic.AddInt64(&b.queued, 1) > b.conf.MaxQueue {
+		atomic.AddInt64(&b.queued, -1)
+		b.report(true)
+		return false
+	}
+	b.report(false)
+
+	var timeout <-chan time.Time
+	if b.conf.QueueTimeout > 0 {
+		timer := time.NewTimer(b.conf.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	acquired := false
+	select {
+	case b.slots <- struct{}{}:
+		acquired = true
+	case <-timeout:
+	case <-ctx.Done():
+	}
+	atomic.AddInt64(&b.queued, -1)
+	b.report(!acquired)
+	return acquired
+}
+
+// Release - 할당된 처리 슬롯 반환
+func (b *Bulkhead) Release() {
+	<-b.slots
+	b.report(false)
+}
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// ParseConfig - Bulkhead 운영을 위한 Configuration parsing 처리 (설정이 없는 경우는 nil, 잘못된 설정인 경우는 오류 반환)
+func ParseConfig(mwConf config.MWConfig) (*Config, error) {
+	conf := new(Config)
+	tmp, ok := mwConf[MWNamespace]
+	if !ok {
+		return nil, nil
+	}
+
+	buf := new(bytes.Buffer)
+	yaml.NewEncoder(buf).Encode(tmp)
+	if err := yaml.NewDecoder(buf).Decode(conf); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s configuration", MWNamespace)
+	}
+
+	return conf, nil
+}
+
+// NewBulkhead - 지정한 설정으로 동시 처리 요청 수를 제한하는 Bulkhead 생성 (설정이 없거나 최대 처리 수가 0 이하인 경우는 nil)
+func NewBulkhead(conf *Config, layer, name string, reporter Reporter) *Bulkhead {
+	if conf == nil || conf.MaxConcurrent <= 0 {
+		return nil
+	}
+	return &Bulkhead{
+		conf:     conf,
+		slots:    make(chan struct{}, conf.MaxConcurrent),
+		layer:    layer,
+		name:     name,
+		reporter: reporter,
+	}
+}
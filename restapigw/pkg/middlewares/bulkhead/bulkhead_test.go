@@ -0,0 +1,34 @@
+package bulkhead
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+)
+
+func TestParseConfig(t *testing.T) {
+	conf, err := ParseConfig(config.MWConfig{MWNamespace: map[string]interface{}{"max_concurrent": 10, "max_queue": 5, "queue_timeout": "500ms"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if *conf != (Config{MaxConcurrent: 10, MaxQueue: 5, QueueTimeout: 500 * time.Millisecond}) {
+		t.Errorf("unexpected config: %+v", conf)
+	}
+
+	// 설정이 없는 경우는 오류 없이 nil
+	if conf, err := ParseConfig(config.MWConfig{}); conf != nil || err != nil {
+		t.Errorf("missing config should be nil: %+v, %v", conf, err)
+	}
+
+	// 잘못된 설정은 오류 반환
+	for _, v := range []interface{}{
+		map[string]interface{}{"max_concurrent": "many"},
+		map[string]interface{}{"queue_timeout": "soon"},
+		"10",
+	} {
+		if conf, err := ParseConfig(config.MWConfig{MWNamespace: v}); conf != nil || err == nil {
+			t.Errorf("%v: invalid config should be rejected: %+v", v, conf)
+		}
+	}
+}
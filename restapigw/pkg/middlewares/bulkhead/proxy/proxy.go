@@ -0,0 +1,156 @@
+// Package proxy - Endpoint 및 Backend 호출 구간에 대한 동시 처리 요청 수 제한 (Bulkhead) 적용 패키지
+package proxy
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/bulkhead"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// LayerProxy - Endpoint 구간 식별자
+	LayerProxy = "proxy"
+	// LayerBackend - Backend 구간 식별자
+	LayerBackend = "backend"
+)
+
+var (
+	logger = logging.NewLogger()
+)
+
+// ===== [ Types ] =====
+
+// releaseReadCloser - Close 호출시 Bulkhead 처리 슬롯을 반환하는 Response io.Reader 구조 (Streaming 응답)
+type releaseReadCloser struct {
+	io.Reader
+	release func()
+}
+
+// releaseReadWriteCloser - Close 호출시 Bulkhead 처리 슬롯을 반환하는 Response io.ReadWriteCloser 구조 (Upgrade 연결)
+type releaseReadWriteCloser struct {
+	io.ReadWriteCloser
+	release func()
+}
+
+// ===== [ Implementations ] =====
+
+// Close - 원본 Reader를 닫고 (io.Closer인 경우) Bulkhead 처리 슬롯 반환
+func (rc releaseReadCloser) Close() error {
+	var err error
+	if c, ok := rc.Reader.(io.Closer); ok {
+		err = c.Close()
+	}
+	rc.release()
+	return err
+}
+
+// Close - 원본 연결을 닫고 Bulkhead 처리 슬롯 반환
+func (rwc releaseReadWriteCloser) Close() error {
+	err := rwc.ReadWriteCloser.Close()
+	rwc.release()
+	return err
+}
+
+// ===== [ Private Functions ] =====
+
+// holdUntilClosed - Response의 io.Reader (Streaming 응답, Upgrade 연결) 가 닫히거나 Context가 종료될 때까지 Bulkhead 처리 슬롯을 유지하도록 Response 구성
+func holdUntilClosed(ctx context.Context, b *bulkhead.Bulkhead, res *proxy.Response) {
+	var once sync.Once
+	closed := make(chan struct{})
+	release := func() {
+		once.Do(func() {
+			close(closed)
+			b.Release()
+		})
+	}
+	if done := ctx.Done(); done != nil {
+		go func() {
+			select {
+			case <-done:
+				release()
+			case <-closed:
+			}
+		}()
+	}
+
+	if rwc, ok := res.Io.(io.ReadWriteCloser); ok {
+		res.Io = releaseReadWriteCloser{ReadWriteCloser: rwc, release: release}
+		return
+	}
+	res.Io = releaseReadCloser{Reader: res.Io, release: release}
+}
+
+// newBulkheadChain - 지정한 Bulkhead로 동시 처리 요청 수를 제한하는 Proxy 호출 체인 생성
+func newBulkheadChain(b *bulkhead.Bulkhead, name string, errSaturated error) proxy.CallChain {
+	if b == nil {
+		return proxy.EmptyChain
+	}
+	return func(next ...proxy.Proxy) proxy.Proxy {
+		if len(next) > 1 {
+			panic(proxy.ErrTooManyProxies)
+		}
+		return func(ctx context.Context, req *proxy.Request) (*proxy.Response, error) {
+			if !b.Acquire(ctx) {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				logger.Warnf("[CallChain] Bulkhead > %s ::: SATURATED!!", name)
+				return nil, errSaturated
+			}
+			res, err := next[0](ctx, req)
+			if err != nil || res == nil || res.Io == nil {
+				b.Release()
+				return res, err
+			}
+			holdUntilClosed(ctx, b, res)
+			return res, nil
+		}
+	}
+}
+
+// ===== [ Public Functions ] =====
+
+// NewBackendBulkhead - Backend 호출에 대한 동시 처리 요청 수 제한 기능을 제공하는 Middleware 생성
+func NewBackendBulkhead(bConf *config.BackendConfig, reporter bulkhead.Reporter) proxy.CallChain {
+	conf, err := bulkhead.ParseConfig(bConf.Middleware)
+	if err != nil {
+		logger.Errorf("[CallChain] Bulkhead > Disabled on backend %s: %s", bConf.URLPattern, err.Error())
+	}
+	b := bulkhead.NewBulkhead(conf, LayerBackend, bConf.URLPattern, reporter)
+	return newBulkheadChain(b, bConf.URLPattern, bulkhead.ErrBackendSaturated)
+}
+
+// NewEndpointBulkhead - Endpoint 처리에 대한 동시 처리 요청 수 제한 기능을 제공하는 Middleware 생성
+func NewEndpointBulkhead(eConf *config.EndpointConfig, reporter bulkhead.Reporter) proxy.CallChain {
+	conf, err := bulkhead.ParseConfig(eConf.Middleware)
+	if err != nil {
+		logger.Errorf("[CallChain] Bulkhead > Disabled on endpoint %s: %s", eConf.Endpoint, err.Error())
+	}
+	b := bulkhead.NewBulkhead(conf, LayerProxy, eConf.Endpoint, reporter)
+	return newBulkheadChain(b, eConf.Endpoint, bulkhead.ErrProxySaturated)
+}
+
+// BackendFactory - Proxy에서 운영될 Bulkhead 기능이 적용된 Backend Factory 생성
+func BackendFactory(next proxy.BackendFactory, reporter bulkhead.Reporter) proxy.BackendFactory {
+	return func(bConf *config.BackendConfig) proxy.Proxy {
+		return NewBackendBulkhead(bConf, reporter)(next(bConf))
+	}
+}
+
+// ProxyFactory - Bulkhead 기능이 적용된 Proxy Factory 생성
+func ProxyFactory(next proxy.Factory, reporter bulkhead.Reporter) proxy.FactoryFunc {
+	return proxy.FactoryFunc(func(eConf *config.EndpointConfig) (proxy.Proxy, error) {
+		p, err := next.New(eConf)
+		if err != nil {
+			return proxy.DummyProxy, err
+		}
+		return NewEndpointBulkhead(eConf, reporter)(p), nil
+	})
+}
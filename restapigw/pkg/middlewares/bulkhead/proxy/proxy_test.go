@@ -0,0 +1,100 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/bulkhead"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+)
+
+func newSingleSlotChain(body func() io.Reader) proxy.Proxy {
+	b := bulkhead.NewBulkhead(&bulkhead.Config{MaxConcurrent: 1}, LayerProxy, "/stream", nil)
+	return newBulkheadChain(b, "/stream", bulkhead.ErrProxySaturated)(func(_ context.Context, _ *proxy.Request) (*proxy.Response, error) {
+		return &proxy.Response{IsComplete: true, Io: body()}, nil
+	})
+}
+
+func TestBulkheadChain_holdUntilClosed(t *testing.T) {
+	p := newSingleSlotChain(func() io.Reader { return ioutil.NopCloser(strings.NewReader("data")) })
+
+	res, err := p(context.Background(), &proxy.Request{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := p(context.Background(), &proxy.Request{}); err != bulkhead.ErrProxySaturated {
+		t.Fatalf("the slot should be held until the response is closed, got: %v", err)
+	}
+
+	res.Io.(io.Closer).Close()
+	res.Io.(io.Closer).Close()
+	if _, err := p(context.Background(), &proxy.Request{}); err != nil {
+		t.Fatalf("the slot should be released after the response is closed, got: %v", err)
+	}
+}
+
+func TestBulkheadChain_releaseOnContextDone(t *testing.T) {
+	p := newSingleSlotChain(func() io.Reader { return strings.NewReader("data") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := p(ctx, &proxy.Request{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		_, err := p(context.Background(), &proxy.Request{})
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the slot should be released when the context is done, got: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBulkheadChain_keepUpgradedConn(t *testing.T) {
+	client, backend := net.Pipe()
+	defer client.Close()
+	p := newSingleSlotChain(func() io.Reader { return backend })
+
+	res, err := p(context.Background(), &proxy.Request{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conn, ok := res.Io.(io.ReadWriteCloser)
+	if !ok {
+		t.Fatal("the upgraded connection should remain an io.ReadWriteCloser")
+	}
+	conn.Close()
+	if _, err := p(context.Background(), &proxy.Request{}); err != nil {
+		t.Fatalf("the slot should be released after the connection is closed, got: %v", err)
+	}
+}
+
+func TestNewEndpointBulkhead_invalidConfig(t *testing.T) {
+	eConf := &config.EndpointConfig{Endpoint: "/invalid", Middleware: config.MWConfig{bulkhead.MWNamespace: map[string]interface{}{"max_concurrent": "many"}}}
+	calls := 0
+	p := NewEndpointBulkhead(eConf, nil)(func(_ context.Context, _ *proxy.Request) (*proxy.Response, error) {
+		calls++
+		return &proxy.Response{IsComplete: true}, nil
+	})
+
+	// 잘못된 설정은 오류 Log를 남기고 제한 없이 처리
+	for i := 0; i < 3; i++ {
+		if _, err := p(context.Background(), &proxy.Request{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("requests should be passed without limit: %d", calls)
+	}
+}
@@ -45,6 +45,22 @@ func (rw *responseWriter) end() {
 	rw.rm.Histogram("response", rw.name, "time").Update(int64(duration))
 }
 
+// UpgradeOpened - Upgrade 연결 (WebSocket 등) 시작 Metric 처리
+func (rw *responseWriter) UpgradeOpened() {
+	rw.rm.RegisterUpgradeMetrics(rw.name)
+	rw.rm.UpgradeOpened(rw.name)
+}
+
+// UpgradeClosed - Upgrade 연결 종료 Metric 처리
+func (rw *responseWriter) UpgradeClosed() {
+	rw.rm.UpgradeClosed(rw.name)
+}
+
+// UpgradeTransferred - Upgrade 연결 전송량 Metric 처리
+func (rw *responseWriter) UpgradeTransferred(direction string, n int64) {
+	rw.rm.UpgradeTransferred(rw.name, direction, n)
+}
+
 // HandlerFactory - 전달된 HandlerFactory 수행 전에 필요한 Metric 관련 처리를 수행하는 HandlerFactory 구성
 func (c *Collector) HandlerFactory(hf ginRouter.HandlerFactory, log logging.Logger) ginRouter.HandlerFactory {
 	if c.Config == nil || !c.Config.RouterEnabled {
@@ -8,7 +8,9 @@ import (
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/bulkhead"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/sd"
 	"github.com/rcrowley/go-metrics"
 )
 
@@ -58,11 +60,103 @@ func (p *Producer) BackendFactory(segmentName string, next proxy.BackendFactory)
 	}
 }
 
+// MirrorReporter - Mirror (Shadow) 요청 처리 결과를 Metrics로 수집하는 Reporter 생성 (Backend Metrics 비활성인 경우는 nil)
+func (p *Producer) MirrorReporter() proxy.MirrorReporter {
+	if p.Config == nil || !p.Config.BackendEnabled {
+		return nil
+	}
+
+	return func(r proxy.MirrorResult) {
+		labels := "mirror." + r.Name
+		if r.Dropped {
+			p.Proxy.Counter(labels, "dropped", "count").Inc(1)
+			return
+		}
+		p.Proxy.Counter(labels, "status", strconv.Itoa(r.StatusCode), "count").Inc(1)
+		p.Proxy.Histogram(labels, "latency").Update(r.Duration.Nanoseconds())
+		if r.Err != nil {
+			p.Proxy.Counter(labels, "error", "count").Inc(1)
+		}
+		if r.Compared {
+			p.Proxy.Counter(labels, "diff", strconv.FormatBool(r.Matched), "count").Inc(1)
+		}
+	}
+}
+
+// VariantReporter - 트래픽 분할 Variant 별 처리 결과를 Metrics로 수집하는 Reporter 생성 (Proxy Metrics 비활성인 경우는 nil)
+func (p *Producer) VariantReporter() proxy.VariantReporter {
+	if p.Config == nil || !p.Config.ProxyEnabled {
+		return nil
+	}
+
+	return func(endpoint, variant string, duration time.Duration, res *proxy.Response, err error) {
+		labels := "split." + endpoint + ".variant." + variant
+		p.Proxy.Counter(labels, "requests", "count").Inc(1)
+		p.Proxy.Histogram(labels, "latency").Update(duration.Nanoseconds())
+		if err != nil || res == nil || !res.IsComplete {
+			p.Proxy.Counter(labels, "errors", "count").Inc(1)
+		}
+	}
+}
+
+// RetryReporter - Backend 재 시도 결과를 Metrics로 수집하는 Reporter 생성 (Backend Metrics 비활성인 경우는 nil)
+func (p *Producer) RetryReporter() proxy.RetryReporter {
+	if p.Config == nil || !p.Config.BackendEnabled {
+		return nil
+	}
+
+	return func(name string, retries int, succeeded bool) {
+		labels := "retry." + name
+		p.Proxy.Counter(labels, "count").Inc(int64(retries))
+		p.Proxy.Counter(labels, "requests", "count").Inc(1)
+		if !succeeded {
+			p.Proxy.Counter(labels, "exhausted", "count").Inc(1)
+		}
+	}
+}
+
+// BreakerReporter - Backend Host 별 Circuit 상태 전환을 Metrics로 수집하는 Reporter 생성 (Backend Metrics 비활성인 경우는 nil)
+func (p *Producer) BreakerReporter() sd.BreakerReporter {
+	if p.Config == nil || !p.Config.BackendEnabled {
+		return nil
+	}
+
+	return func(name, host string, from, to sd.BreakerState) {
+		labels := "breaker." + name + "." + host
+		p.Proxy.Gauge(labels, "state").Update(int64(to))
+		p.Proxy.Counter(labels, to.String(), "count").Inc(1)
+	}
+}
+
+// BulkheadReporter - Endpoint/Backend 별 처리 중/대기 중인 요청 수와 거부 건수를 Metrics로 수집하는 Reporter 생성 (해당 구간의 Metrics 비활성인 경우는 수집 제외)
+func (p *Producer) BulkheadReporter() bulkhead.Reporter {
+	if p.Config == nil || (!p.Config.ProxyEnabled && !p.Config.BackendEnabled) {
+		return nil
+	}
+
+	return func(layer, name string, inFlight, queued int64, rejected bool) {
+		if (layer == "proxy" && !p.Config.ProxyEnabled) || (layer == "backend" && !p.Config.BackendEnabled) {
+			return
+		}
+		labels := "bulkhead." + layer + "." + name
+		p.Proxy.Gauge(labels, "inflight").Update(inFlight)
+		p.Proxy.Gauge(labels, "queued").Update(queued)
+		if rejected {
+			p.Proxy.Counter(labels, "rejected", "count").Inc(1)
+		}
+	}
+}
+
 // Counter - Metric Counter가 없는 경우는 등록하고 대상 Counter 반환
 func (pm *ProxyMetrics) Counter(labels ...string) metrics.Counter {
 	return metrics.GetOrRegisterCounter(strings.Join(labels, "."), pm.register)
 }
 
+// Gauge - Metric Gauge가 없는 경우는 등록하고 대상 Gauge 반환
+func (pm *ProxyMetrics) Gauge(labels ...string) metrics.Gauge {
+	return metrics.GetOrRegisterGauge(strings.Join(labels, "."), pm.register)
+}
+
 // Histogram - Metric Histogram이 없는 경우는 등록하고 대상 Histogram 반환
 func (pm *ProxyMetrics) Histogram(labels ...string) metrics.Histogram {
 	return metrics.GetOrRegisterHistogram(strings.Join(labels, "."), pm.register, defaultSample())
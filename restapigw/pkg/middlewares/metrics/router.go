@@ -59,6 +59,30 @@ func (rm *RouterMetrics) RegisterResponseWriterMetrics(name string) {
 	rm.Histogram("response", name, "time")
 }
 
+// RegisterUpgradeMetrics - Upgrade 연결 (WebSocket 등) 에 연동되는 Metric 설정
+func (rm *RouterMetrics) RegisterUpgradeMetrics(name string) {
+	rm.Counter("upgrade", name, "open")
+	rm.Counter("upgrade", name, "total")
+	rm.Counter("upgrade", name, "bytes", "in")
+	rm.Counter("upgrade", name, "bytes", "out")
+}
+
+// UpgradeOpened - Upgrade 연결이 시작된 경우에 열린 연결 수와 전체 연결 수 증가 처리
+func (rm *RouterMetrics) UpgradeOpened(name string) {
+	rm.Counter("upgrade", name, "open").Inc(1)
+	rm.Counter("upgrade", name, "total").Inc(1)
+}
+
+// UpgradeClosed - Upgrade 연결이 종료된 경우에 열린 연결 수 감소 처리
+func (rm *RouterMetrics) UpgradeClosed(name string) {
+	rm.Counter("upgrade", name, "open").Dec(1)
+}
+
+// UpgradeTransferred - Upgrade 연결의 방향 ("in": 클라이언트 -> Backend, "out": Backend -> 클라이언트) 별 전송량 증가 처리
+func (rm *RouterMetrics) UpgradeTransferred(name, direction string, n int64) {
+	rm.Counter("upgrade", name, "bytes", direction).Inc(n)
+}
+
 // ===== [ Private Functions ] =====
 
 // ===== [ Public Functions ] =====
@@ -3,6 +3,7 @@ package proxy
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -15,7 +16,16 @@ const ()
 var ()
 
 // ===== [ Types ] =====
-type ()
+type (
+	// selectedHostKey - Load Balancer가 선택한 Host 정보를 Context에 보관하기 위한 Key 형식
+	selectedHostKey struct{}
+
+	// selectedHost - Load Balancer가 선택한 Host 정보와 호출 결과 전달 함수 (Hedged Request 처리시 실제 응답한 Host로 변경)
+	selectedHost struct {
+		host string
+		done func(sd.HostResult)
+	}
+)
 
 // ===== [ Implementations ] =====
 // ===== [ Private Functions ] =====
@@ -28,7 +38,7 @@ func newLoadBalancedChain(sb sd.Balancer) CallChain {
 		}
 
 		return func(ctx context.Context, req *Request) (*Response, error) {
-			host, err := sb.Host()
+			host, done, err := selectHost(sb)
 			if err != nil {
 				return nil, err
 			}
@@ -40,20 +50,57 @@ func newLoadBalancedChain(sb sd.Balancer) CallChain {
 			b.WriteString(r.Path)
 			r.URL, err = url.Parse(b.String())
 			if err != nil {
+				done(sd.HostIgnored)
 				return nil, err
 			}
 			if len(r.Query) > 0 {
 				r.URL.RawQuery += "&" + r.Query.Encode()
 			}
 
-			return next[0](ctx, &r)
+			sh := &selectedHost{host: host, done: done}
+			res, err := next[0](context.WithValue(ctx, selectedHostKey{}, sh), &r)
+
+			// Circuit Breaker가 적용된 Balancer로 Host 호출 결과 전달
+			sh.done(hostResult(ctx, res, err))
+			return res, err
 		}
 	}
 }
 
+// selectHost - Balancer로 Host 선택 (Circuit Breaker가 적용된 Balancer는 호출 결과 전달 함수 포함, 그 외는 처리 없는 함수 반환)
+func selectHost(sb sd.Balancer) (string, func(sd.HostResult), error) {
+	if hs, ok := sb.(sd.HostSelector); ok {
+		return hs.Select()
+	}
+	host, err := sb.Host()
+	return host, func(sd.HostResult) {}, err
+}
+
+// hostResult - Host 호출 결과를 Circuit Breaker 판단 기준으로 분류 (Network 오류, 처리 시간 초과, 5xx 응답은 실패, 클라이언트 취소는 무시)
+func hostResult(ctx context.Context, res *Response, err error) sd.HostResult {
+	if err == context.Canceled || ctx.Err() == context.Canceled {
+		return sd.HostIgnored
+	}
+	if err != nil {
+		if isNetworkError(err) || errorStatusCode(err) >= http.StatusInternalServerError {
+			return sd.HostFailed
+		}
+		return sd.HostSucceeded
+	}
+	if res != nil && res.Metadata.StatusCode >= http.StatusInternalServerError {
+		return sd.HostFailed
+	}
+	return sd.HostSucceeded
+}
+
 // ===== [ Public Functions ] =====
 
 // NewLoadBalancedChainWithSubscriber - 지정된 Subscriber를 활용하는 Loadbalacer Chain 구성
 func NewLoadBalancedChainWithSubscriber(subscriber sd.Subscriber) CallChain {
 	return newLoadBalancedChain(sd.NewBalancer(subscriber))
 }
+
+// NewLoadBalancedChain - 지정된 Balancer를 활용하는 Loadbalancer Chain 구성
+func NewLoadBalancedChain(sb sd.Balancer) CallChain {
+	return newLoadBalancedChain(sb)
+}
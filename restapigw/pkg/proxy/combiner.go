@@ -0,0 +1,161 @@
+// Package proxy - 여러 Backend의 Response를 하나로 병합하는 기본 제공 ResponseCombiner 패키지
+package proxy
+
+import (
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// FirstWinsCombinerName - 최상위 키 충돌 시 먼저 처리된 Backend 값을 유지하는 Combiner 식별자
+	FirstWinsCombinerName = "first_wins"
+	// LastWinsCombinerName - 최상위 키 충돌 시 나중에 처리된 Backend 값으로 교체하는 Combiner 식별자 (default와 동일)
+	LastWinsCombinerName = "last_wins"
+	// DeepMergeCombinerName - 중첩된 객체를 재귀적으로 병합하고 충돌 시 나중 값으로 교체하는 Combiner 식별자
+	DeepMergeCombinerName = "deep_merge"
+	// DeepMergeFirstWinsCombinerName - 중첩된 객체를 재귀적으로 병합하고 충돌 시 먼저 처리된 값을 유지하는 Combiner 식별자
+	DeepMergeFirstWinsCombinerName = "deep_merge_first_wins"
+	// ConcatCollectionCombinerName - core.CollectionTag 의 Array들을 연결하고 나머지는 deep_merge로 처리하는 Combiner 식별자
+	ConcatCollectionCombinerName = "concat_collection"
+
+	combinerKey = "combiner"
+)
+
+var (
+	// ErrUnknownCombiner - Endpoint 설정의 "mw-proxy.combiner" 에 등록되지 않은 Combiner가 지정된 경우 오류
+	ErrUnknownCombiner = errors.New("unknown response combiner")
+)
+
+// ===== [ Types ] =====
+
+// mergeOptions - 기본 제공 Combiner의 병합 방식 설정 구조
+type mergeOptions struct {
+	// 중첩 객체 재귀 병합 여부
+	deep bool
+	// 충돌 시 먼저 처리된 값 유지 여부
+	firstWins bool
+	// core.CollectionTag Array 연결 여부
+	concatCollection bool
+}
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// mergeValues - 지정한 병합 방식에 따라 src 맵의 데이터를 dst 맵에 반영
+func mergeValues(opts mergeOptions, dst, src map[string]interface{}, isRoot bool) {
+	for k, v := range src {
+		former, exists := dst[k]
+		if !exists {
+			dst[k] = v
+			continue
+		}
+
+		// Collection 연결 처리 (최상위 레벨만 대상)
+		if isRoot && opts.concatCollection && k == core.CollectionTag {
+			if fc, ok := former.([]interface{}); ok {
+				if vc, ok := v.([]interface{}); ok {
+					merged := make([]interface{}, 0, len(fc)+len(vc))
+					merged = append(merged, fc...)
+					dst[k] = append(merged, vc...)
+					continue
+				}
+			}
+		}
+
+		// 중첩 객체 재귀 병합
+		if opts.deep {
+			if fm, ok := former.(map[string]interface{}); ok {
+				if vm, ok := v.(map[string]interface{}); ok {
+					mergeValues(opts, fm, vm, false)
+					continue
+				}
+			}
+		}
+
+		if !opts.firstWins {
+			dst[k] = v
+		}
+	}
+}
+
+// newResponseCombiner - 지정한 병합 방식을 사용하는 ResponseCombiner 생성
+func newResponseCombiner(opts mergeOptions) ResponseCombiner {
+	return func(backendCount int, reses []*Response) *Response {
+		isComplete := len(reses) == backendCount
+		var mergedResponse *Response
+		for _, res := range reses {
+			if res == nil || res.Data == nil {
+				isComplete = false
+				continue
+			}
+
+			isComplete = isComplete && res.IsComplete
+			if mergedResponse == nil {
+				mergedResponse = res
+				continue
+			}
+
+			mergeValues(opts, mergedResponse.Data, res.Data, true)
+		}
+
+		if mergedResponse == nil {
+			// do not allow nil data to response
+			return &Response{Data: make(map[string]interface{}), IsComplete: isComplete}
+		}
+		mergedResponse.IsComplete = isComplete
+		return mergedResponse
+	}
+}
+
+// defaultResponseCombiners - 기본 제공되는 ResponseCombiner 목록 반환
+func defaultResponseCombiners() map[string]ResponseCombiner {
+	return map[string]ResponseCombiner{
+		defaultCombinerName:            combineData,
+		LastWinsCombinerName:           combineData,
+		FirstWinsCombinerName:          newResponseCombiner(mergeOptions{firstWins: true}),
+		DeepMergeCombinerName:          newResponseCombiner(mergeOptions{deep: true}),
+		DeepMergeFirstWinsCombinerName: newResponseCombiner(mergeOptions{deep: true, firstWins: true}),
+		ConcatCollectionCombinerName:   newResponseCombiner(mergeOptions{deep: true, concatCollection: true}),
+	}
+}
+
+// getCombinerName - 지정된 Endpoint 설정에서 사용할 ResponseCombiner 이름 추출 (미 지정시 "default")
+func getCombinerName(eConf *config.EndpointConfig) string {
+	if v, ok := eConf.Middleware[MWNamespace]; ok {
+		if e, ok := v.(config.MWConfig); ok {
+			if name, ok := e[combinerKey].(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+	return defaultCombinerName
+}
+
+// validateCombiner - 지정된 Endpoint 설정의 "combiner" 가 등록된 ResponseCombiner 인지 검증
+func validateCombiner(eConf *config.EndpointConfig) error {
+	if v, ok := eConf.Middleware[MWNamespace]; ok {
+		if e, ok := v.(config.MWConfig); ok {
+			if name, ok := e[combinerKey]; ok {
+				if s, ok := name.(string); !ok || s == "" {
+					return errors.Wrapf(ErrUnknownCombiner, "'%v' on %s", name, eConf.Endpoint)
+				}
+			}
+		}
+	}
+	name := getCombinerName(eConf)
+	if _, ok := responseCombiners.GetResponseCombiner(name); !ok {
+		return errors.Wrapf(ErrUnknownCombiner, "'%s' on %s", name, eConf.Endpoint)
+	}
+	return nil
+}
+
+// ===== [ Public Functions ] =====
+
+// RegisterResponseCombiner - 지정한 이름으로 사용자 정의 ResponseCombiner 등록 (Endpoint 설정의 "mw-proxy.combiner" 로 선택)
+func RegisterResponseCombiner(name string, rc ResponseCombiner) {
+	responseCombiners.Register(name, rc)
+}
@@ -0,0 +1,112 @@
+package proxy
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+)
+
+// combinerResponses - 최상위 키, 중첩 객체, 컬랙션이 충돌하는 Backend 응답들 구성
+func combinerResponses() []*Response {
+	return []*Response{
+		{Data: map[string]interface{}{
+			"a":          1.0,
+			"nested":     map[string]interface{}{"x": 1.0, "y": 1.0},
+			"collection": []interface{}{1.0},
+		}, IsComplete: true},
+		{Data: map[string]interface{}{
+			"a":          2.0,
+			"b":          2.0,
+			"nested":     map[string]interface{}{"y": 2.0, "z": 2.0},
+			"collection": []interface{}{2.0},
+		}, IsComplete: true},
+	}
+}
+
+func TestResponseCombiners(t *testing.T) {
+	lastWins := map[string]interface{}{"a": 2.0, "b": 2.0, "nested": map[string]interface{}{"y": 2.0, "z": 2.0}, "collection": []interface{}{2.0}}
+
+	for name, expected := range map[string]map[string]interface{}{
+		defaultCombinerName:            lastWins,
+		LastWinsCombinerName:           lastWins,
+		FirstWinsCombinerName:          {"a": 1.0, "b": 2.0, "nested": map[string]interface{}{"x": 1.0, "y": 1.0}, "collection": []interface{}{1.0}},
+		DeepMergeCombinerName:          {"a": 2.0, "b": 2.0, "nested": map[string]interface{}{"x": 1.0, "y": 2.0, "z": 2.0}, "collection": []interface{}{2.0}},
+		DeepMergeFirstWinsCombinerName: {"a": 1.0, "b": 2.0, "nested": map[string]interface{}{"x": 1.0, "y": 1.0, "z": 2.0}, "collection": []interface{}{1.0}},
+		ConcatCollectionCombinerName:   {"a": 2.0, "b": 2.0, "nested": map[string]interface{}{"x": 1.0, "y": 2.0, "z": 2.0}, "collection": []interface{}{1.0, 2.0}},
+	} {
+		rc, ok := responseCombiners.GetResponseCombiner(name)
+		if !ok {
+			t.Fatalf("%s: combiner not registered", name)
+		}
+
+		res := rc(2, combinerResponses())
+		if !res.IsComplete || !reflect.DeepEqual(res.Data, expected) {
+			t.Errorf("%s: unexpected result: %v", name, res.Data)
+		}
+
+		// 불완전하거나 누락된 응답이 있는 경우는 불완전 처리
+		reses := combinerResponses()
+		reses[1].IsComplete = false
+		if res := rc(2, reses); res.IsComplete {
+			t.Errorf("%s: incomplete response should make the result incomplete", name)
+		}
+		if res := rc(3, combinerResponses()); res.IsComplete {
+			t.Errorf("%s: missing response should make the result incomplete", name)
+		}
+		if res := rc(2, []*Response{nil, nil}); res.Data == nil || res.IsComplete {
+			t.Errorf("%s: empty responses should be combined to empty data: %+v", name, res)
+		}
+	}
+}
+
+func newCombinerEndpoint(name string) *config.EndpointConfig {
+	return &config.EndpointConfig{
+		Endpoint:   "/combiner",
+		Timeout:    time.Second,
+		Middleware: newProxyMiddleware(config.MWConfig{combinerKey: name}),
+		Backend: []*config.BackendConfig{
+			{URLPattern: "/a", Hosts: []*config.HostConfig{{Host: "http://a"}}},
+			{URLPattern: "/b", Hosts: []*config.HostConfig{{Host: "http://b"}}},
+		},
+	}
+}
+
+func TestRegisterResponseCombiner(t *testing.T) {
+	RegisterResponseCombiner("test_count", func(backendCount int, reses []*Response) *Response {
+		return &Response{Data: map[string]interface{}{"count": len(reses)}, IsComplete: true}
+	})
+
+	bf := func(_ *config.BackendConfig) Proxy {
+		return func(_ context.Context, _ *Request) (*Response, error) {
+			return &Response{Data: map[string]interface{}{"ok": true}, IsComplete: true}, nil
+		}
+	}
+	p, err := NewDefaultFactory(bf, *logging.NewLogger()).New(newCombinerEndpoint("test_count"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	res, err := p(context.Background(), &Request{Params: map[string]string{}, Headers: map[string][]string{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if res.Data["count"] != 2 {
+		t.Errorf("registered combiner should be used: %v", res.Data)
+	}
+}
+
+func TestFactory_unknownCombiner(t *testing.T) {
+	bf := func(_ *config.BackendConfig) Proxy { return DummyProxy }
+
+	for _, name := range []interface{}{"unknown", "", 1} {
+		eConf := newCombinerEndpoint("")
+		eConf.Middleware[MWNamespace].(config.MWConfig)[combinerKey] = name
+		if _, err := NewDefaultFactory(bf, *logging.NewLogger()).New(eConf); errors.Cause(err) != ErrUnknownCombiner {
+			t.Errorf("%v: unknown combiner should be rejected: %v", name, err)
+		}
+	}
+}
@@ -13,8 +13,22 @@ import (
 // defaultFactory - logging과 BackendFactory로 구성된 기본 팩토리 구조
 type defaultFactory struct {
 	backendFactory    BackendFactory
+	shadowFactory     BackendFactory
 	logger            logging.Logger
 	subscriberFactory sd.SubscriberFactory
+	reporters         Reporters
+}
+
+// Reporters - Proxy 처리 결과를 수집 (Metrics 연계용) 하기 위한 Reporter 구성 (지정하지 않은 Reporter는 수집하지 않음)
+type Reporters struct {
+	// Variant - 트래픽 분할 Variant 별 처리 결과 Reporter
+	Variant VariantReporter
+	// Retry - Backend 재 시도 결과 Reporter
+	Retry RetryReporter
+	// Breaker - Backend Host 별 Circuit 상태 전환 Reporter
+	Breaker sd.BreakerReporter
+	// Mirror - Mirror (Shadow) 요청 처리 결과 Reporter
+	Mirror MirrorReporter
 }
 
 // Factory - 지정된 Endpoint 기준으로 Proxy 호출을 위한 함수를 생성하는 팩토리 인터페이스
@@ -32,6 +46,11 @@ func (f FactoryFunc) New(eConf *config.EndpointConfig) (Proxy, error) { return f
 
 // newMulti - 여러 Backend로 구성되고 반환 결과를 Merge 처리하는 Proxy 구성
 func (df defaultFactory) newMulti(eConf *config.EndpointConfig) (p Proxy, err error) {
+	// 잘못된 Combiner는 기본 Combiner로 대체하지 않고 Endpoint 구성 실패로 처리
+	if err = validateCombiner(eConf); err != nil {
+		return
+	}
+
 	backendProxy := make([]Proxy, len(eConf.Backend))
 	for i, backend := range eConf.Backend {
 		backendProxy[i] = df.newStack(backend)
@@ -42,23 +61,61 @@ func (df defaultFactory) newMulti(eConf *config.EndpointConfig) (p Proxy, err er
 
 // newSingle - 단일 Backend로 구성되는 Proxy 구성
 func (df defaultFactory) newSingle(eConf *config.EndpointConfig) (Proxy, error) {
-	return df.newStack(eConf.Backend[0]), nil
+	return NewResponseHeaderChain(eConf)(df.newStack(eConf.Backend[0])), nil
 }
 
 // newStack - Backend 호출을 위한 Proxy 구성
 func (df defaultFactory) newStack(bConf *config.BackendConfig) (p Proxy) {
 	p = df.backendFactory(bConf)
 
-	// Load Balancer 설정
-	p = NewLoadBalancedChainWithSubscriber(df.subscriberFactory(bConf))(p)
+	// Load Balancer 설정 (Mock Backend는 Host가 없으므로 제외, Circuit Breaker 설정시 Open 된 Host 제외)
+	if !bConf.IsMock() {
+		sb := sd.NewBalancerWithBreaker(df.subscriberFactory(bConf), bConf.URLPattern, bConf.CircuitBreaker, df.reporters.Breaker)
+
+		// 응답이 지연되는 경우 다른 Host로 추가 요청하는 Hedged Request 설정
+		p = NewHedgedChain(bConf, sb)(p)
+		p = NewLoadBalancedChain(sb)(p)
+	}
+
+	// 실패한 호출을 다른 Host로 재 시도하는 Retry 설정 (Load Balancer 앞에서 재 시도마다 Host 재 선택)
+	p = NewRetryChain(bConf, df.reporters.Retry)(p)
+
+	// 요청 복제본을 Shadow Host로 비동기 전달하는 Mirror 설정 (Retry, Hedge와 무관하게 클라이언트 요청 당 한번만 복제)
+	if bConf.Mirror != nil {
+		mConf := newMirrorBackendConfig(bConf)
+		shadow := NewLoadBalancedChainWithSubscriber(df.subscriberFactory(mConf))(df.shadowFactory(mConf))
+		p = NewMirrorChain(bConf, shadow, df.reporters.Mirror)(p)
+	}
 
 	// Backend 호출을 위한 Request Call chain 구성
 	p = NewRequestBuilderChain(bConf)(p)
 	return
 }
 
-// New - 지정된 Endpoint 설정을 기준으로 동작하는 Proxy 생성 (Backend 설정 갯수에 따라서 single/multi 구분)
+// newSplit - 트래픽 분할 설정의 Variant 별 Proxy와 기본 Backend 설정의 Proxy 중에서 요청 별로 선택하는 Proxy 구성
+func (df defaultFactory) newSplit(eConf *config.EndpointConfig) (Proxy, error) {
+	dConf := *eConf
+	dConf.Split = nil
+	defaultProxy, err := df.New(&dConf)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([]Proxy, len(eConf.Split.Variants))
+	for i, v := range eConf.Split.Variants {
+		if variants[i], err = df.New(eConf.VariantEndpoint(v)); err != nil {
+			return nil, err
+		}
+	}
+	return NewSplitProxy(eConf, defaultProxy, variants, df.reporters.Variant), nil
+}
+
+// New - 지정된 Endpoint 설정을 기준으로 동작하는 Proxy 생성 (트래픽 분할 설정이 있으면 Variant 선택, Backend 설정 갯수에 따라서 single/multi 구분)
 func (df defaultFactory) New(cfg *config.EndpointConfig) (p Proxy, err error) {
+	if cfg.Split != nil {
+		return df.newSplit(cfg)
+	}
+
 	switch len(cfg.Backend) {
 	case 0:
 		err = ErrNoBackends
@@ -71,6 +128,9 @@ func (df defaultFactory) New(cfg *config.EndpointConfig) (p Proxy, err error) {
 		return
 	}
 
+	// Endpoint 레벨의 Query 처리
+	p = NewQueryChain(cfg)(p)
+
 	// TODO: Static Content Middleware
 	return
 }
@@ -81,7 +141,18 @@ func (df defaultFactory) New(cfg *config.EndpointConfig) (p Proxy, err error) {
 
 // NewDefaultFactoryWithSubscriber - 지정된 Subscriber를 활용하는 BackendFactory를 사용하는 ProxyFactory 반환
 func NewDefaultFactoryWithSubscriber(bf BackendFactory, logger logging.Logger, sf sd.SubscriberFactory) Factory {
-	return defaultFactory{bf, logger, sf}
+	return NewDefaultFactoryWithReporters(bf, logger, sf, Reporters{})
+}
+
+// NewDefaultFactoryWithReporters - 지정된 Subscriber를 활용하고 처리 결과를 지정한 Reporter들로 전달하는 ProxyFactory 반환
+func NewDefaultFactoryWithReporters(bf BackendFactory, logger logging.Logger, sf sd.SubscriberFactory, reporters Reporters) Factory {
+	return NewDefaultFactoryWithMirror(bf, bf, logger, sf, reporters)
+}
+
+// NewDefaultFactoryWithMirror - NewDefaultFactoryWithReporters 와 동일하며, Mirror (Shadow) 요청은 지정한 Shadow BackendFactory로 처리하는 ProxyFactory 반환
+// (Shadow 요청이 Backend 단위의 Metrics, Rate Limit, Bulkhead 등에 포함되지 않도록 Middleware가 적용되지 않은 BackendFactory 사용)
+func NewDefaultFactoryWithMirror(bf, shadow BackendFactory, logger logging.Logger, sf sd.SubscriberFactory, reporters Reporters) Factory {
+	return defaultFactory{bf, shadow, logger, sf, reporters}
 }
 
 // NewDefaultFactory - 전달된 BackendFactory를 사용하는 기본 ProxyFactory 반환
@@ -0,0 +1,253 @@
+// Package proxy - 이전 Backend 응답의 Collection 항목 별로 Backend를 호출하고 결과를 항목에 포함시키는 Fan-out 패키지
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	fanoutKey = "fanout"
+
+	// 항목 호출 실패시 IsComplete = false 로 처리하고 나머지 결과 사용 (기본값)
+	fanoutFailurePartial = "partial"
+	// 항목 호출 실패시 Backend 전체를 실패로 처리
+	fanoutFailureFail = "fail"
+	// 항목 호출 실패를 무시 (IsComplete 유지)
+	fanoutFailureIgnore = "ignore"
+
+	defaultFanoutConcurrency = 10
+)
+
+var (
+	errFanoutNoCollection = errors.New("fan-out source collection not found")
+)
+
+// ===== [ Types ] =====
+
+// fanoutConfig - Backend의 Fan-out 처리를 위한 설정 구조 (Backend 레벨 "mw-proxy.fanout")
+type fanoutConfig struct {
+	// 항목을 제공할 이전 Backend 순서 (0 부터 시작)
+	Source int `yaml:"source"`
+	// Source 응답에서 항목 배열의 경로 ("." 구분, 기본값: core.CollectionTag)
+	Path string `yaml:"path"`
+	// 항목에 호출 결과를 포함시킬 필드명 (미 지정시 항목에 결과 필드들을 병합)
+	Target string `yaml:"target"`
+	// 동시 호출 수 (기본값: 10)
+	Concurrency int `yaml:"concurrency"`
+	// 항목 단위 호출 Timeout (기본값: Backend Timeout)
+	ItemTimeout time.Duration `yaml:"item_timeout"`
+	// 항목 호출 실패시 처리 방식 ("partial", "fail", "ignore", 기본값: "partial")
+	Failure string `yaml:"failure"`
+}
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// parseFanoutConfig - 지정한 순서의 Backend 설정에서 Fan-out 설정 추출 (미 지정시 nil, 설정 검증은 config.EndpointConfig.Validate 에서 처리)
+func parseFanoutConfig(bIdx int, bConf *config.BackendConfig) *fanoutConfig {
+	v, ok := bConf.Middleware[MWNamespace]
+	if !ok {
+		return nil
+	}
+	e, ok := v.(config.MWConfig)
+	if !ok {
+		return nil
+	}
+	tmp, ok := e[fanoutKey]
+	if !ok {
+		return nil
+	}
+
+	fConf := new(fanoutConfig)
+	buf := new(bytes.Buffer)
+	yaml.NewEncoder(buf).Encode(tmp)
+	if err := yaml.NewDecoder(buf).Decode(fConf); err != nil {
+		logger.Warnf("[API G/W] Proxy > Invalid fan-out config on backend[%d] %s: %s", bIdx, bConf.URLPattern, err.Error())
+		return nil
+	}
+	if fConf.Source < 0 || fConf.Source >= bIdx {
+		logger.Warnf("[API G/W] Proxy > Invalid fan-out source %d on backend[%d] %s (only earlier backends allowed)", fConf.Source, bIdx, bConf.URLPattern)
+		return nil
+	}
+
+	if fConf.Path == "" {
+		fConf.Path = core.CollectionTag
+	}
+	if fConf.Concurrency <= 0 {
+		fConf.Concurrency = defaultFanoutConcurrency
+	}
+	switch fConf.Failure {
+	case fanoutFailureFail, fanoutFailureIgnore:
+	default:
+		fConf.Failure = fanoutFailurePartial
+	}
+	return fConf
+}
+
+// parseFanoutConfigs - 지정한 Backend 설정들의 Fan-out 설정 목록 반환
+func parseFanoutConfigs(backends []*config.BackendConfig) []*fanoutConfig {
+	fConfs := make([]*fanoutConfig, len(backends))
+	for i, b := range backends {
+		fConfs[i] = parseFanoutConfig(i, b)
+	}
+	return fConfs
+}
+
+// lookupItems - 지정한 데이터에서 경로에 해당하는 항목 배열 반환
+func lookupItems(data map[string]interface{}, path string) ([]interface{}, bool) {
+	keys := strings.Split(path, ".")
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := data[k].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		data = next
+	}
+	items, ok := data[keys[len(keys)-1]].([]interface{})
+	return items, ok
+}
+
+// setItems - 지정한 데이터에서 경로에 해당하는 항목 배열을 지정한 항목 배열로 교체 (경로에 항목 배열이 없는 경우는 false)
+func setItems(data map[string]interface{}, path string, items []interface{}) bool {
+	keys := strings.Split(path, ".")
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := data[k].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		data = next
+	}
+	if _, ok := data[keys[len(keys)-1]].([]interface{}); !ok {
+		return false
+	}
+	data[keys[len(keys)-1]] = items
+	return true
+}
+
+// wrapItems - 지정한 항목 배열을 경로에 해당하는 데이터 구조로 구성
+func wrapItems(items []interface{}, path string) map[string]interface{} {
+	keys := strings.Split(path, ".")
+	data := map[string]interface{}{keys[len(keys)-1]: items}
+	for i := len(keys) - 2; i >= 0; i-- {
+		data = map[string]interface{}{keys[i]: data}
+	}
+	return data
+}
+
+// requestFanout - Source Backend 응답의 항목 별로 지정한 Proxy를 동시 호출 수 제한 내에서 호출하고 결과를 항목에 포함시킨 Response 반환
+func requestFanout(ctx context.Context, next Proxy, req *Request, bIdx int, bConf *config.BackendConfig, fConf *fanoutConfig, parts []*Response, out chan<- *partResult) {
+	src := parts[fConf.Source]
+	if src == nil {
+		out <- &partResult{Error: errDependencyFailed}
+		return
+	}
+	items, ok := lookupItems(src.Data, fConf.Path)
+	if !ok {
+		out <- &partResult{Error: errFanoutNoCollection}
+		return
+	}
+
+	results := make([]interface{}, len(items))
+	failed := make([]error, len(items))
+	incomplete := make([]bool, len(items))
+
+	sem := make(chan struct{}, fConf.Concurrency)
+	wg := sync.WaitGroup{}
+ItemLoop:
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			// 객체가 아닌 항목은 그대로 유지
+			results[i] = item
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(items); j++ {
+				results[j] = items[j]
+				failed[j] = ctx.Err()
+			}
+			break ItemLoop
+		}
+
+		wg.Add(1)
+		go func(i int, m map[string]interface{}) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			// Source Backend의 {{.RespN_xxx}} 파라미터는 현재 항목을 기준으로 설정
+			itemParts := make([]*Response, len(parts))
+			copy(itemParts, parts)
+			itemParts[fConf.Source] = &Response{Data: m}
+
+			r := CloneRequest(req)
+			setResponseParams(bConf, bIdx, itemParts, r.Params)
+
+			timeout := fConf.ItemTimeout
+			if timeout <= 0 {
+				timeout = bConf.Timeout
+			}
+			itemCtx, cancel := newTimeoutContext(ctx, timeout)
+			res, err := next(itemCtx, r)
+			cancel()
+			if err == nil && res == nil {
+				err = errNullResult
+			}
+
+			enriched := cloneData(m).(map[string]interface{})
+			if err != nil {
+				failed[i] = err
+				results[i] = enriched
+				return
+			}
+
+			incomplete[i] = !res.IsComplete
+			if fConf.Target != "" {
+				enriched[fConf.Target] = res.Data
+			} else {
+				for k, v := range res.Data {
+					enriched[k] = v
+				}
+			}
+			results[i] = enriched
+		}(i, m)
+	}
+	wg.Wait()
+
+	isComplete := true
+	for i := range items {
+		if failed[i] != nil {
+			logger.Debugf("[CallChain] Fan-out > backend[%d] item[%d] failed: %s", bIdx, i, failed[i].Error())
+			if fConf.Failure == fanoutFailureFail {
+				out <- &partResult{Error: failed[i]}
+				return
+			}
+		}
+		if failed[i] != nil || incomplete[i] {
+			isComplete = false
+		}
+	}
+	if fConf.Failure == fanoutFailureIgnore {
+		isComplete = true
+	}
+
+	out <- &partResult{Response: &Response{Data: wrapItems(results, fConf.Path), IsComplete: isComplete}}
+}
+
+// ===== [ Public Functions ] =====
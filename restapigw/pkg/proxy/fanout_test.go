@@ -0,0 +1,167 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+)
+
+// newFanoutSource - 지정한 수의 항목을 가진 Source Backend 응답 구성
+func newFanoutSource(n int) *Response {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = map[string]interface{}{"id": float64(i)}
+	}
+	return &Response{Data: map[string]interface{}{"collection": items}, IsComplete: true}
+}
+
+// runFanout - 지정한 Fan-out 설정과 Proxy로 Source 응답의 항목 별 호출 결과 반환
+func runFanout(fConf *fanoutConfig, bConf *config.BackendConfig, src *Response, next Proxy) *partResult {
+	out := make(chan *partResult, 1)
+	requestFanout(context.Background(), next, &Request{Params: map[string]string{}}, 1, bConf, fConf, []*Response{src, nil}, out)
+	return <-out
+}
+
+func TestRequestFanout_concurrency(t *testing.T) {
+	var running, peak int32
+	next := func(_ context.Context, req *Request) (*Response, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return &Response{Data: map[string]interface{}{"detail": req.Params["Resp0_id"]}, IsComplete: true}, nil
+	}
+
+	bConf := &config.BackendConfig{URLPattern: "/items/{{.Resp0_id}}"}
+	fConf := &fanoutConfig{Path: "collection", Concurrency: 3, Failure: fanoutFailurePartial}
+	pr := runFanout(fConf, bConf, newFanoutSource(10), next)
+	if pr.Error != nil {
+		t.Fatalf("unexpected error: %s", pr.Error.Error())
+	}
+	if p := atomic.LoadInt32(&peak); p > 3 || p < 2 {
+		t.Errorf("items should be called concurrently up to 3, but %d", p)
+	}
+
+	items, _ := lookupItems(pr.Response.Data, "collection")
+	for i, item := range items {
+		expected := map[string]interface{}{"id": float64(i), "detail": strconv.Itoa(i)}
+		if !reflect.DeepEqual(item, expected) {
+			t.Errorf("unexpected item[%d]: %v", i, item)
+		}
+	}
+}
+
+func TestRequestFanout_failureModes(t *testing.T) {
+	// 홀수 항목은 Timeout 발생
+	next := func(ctx context.Context, req *Request) (*Response, error) {
+		if req.Params["Resp0_id"] == "1" || req.Params["Resp0_id"] == "3" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &Response{Data: map[string]interface{}{"ok": true}, IsComplete: true}, nil
+	}
+	bConf := &config.BackendConfig{URLPattern: "/items/{{.Resp0_id}}", Timeout: time.Second}
+
+	for _, tc := range []struct {
+		failure  string
+		failed   bool
+		complete bool
+	}{
+		{failure: fanoutFailurePartial},
+		{failure: fanoutFailureFail, failed: true},
+		{failure: fanoutFailureIgnore, complete: true},
+	} {
+		fConf := &fanoutConfig{Path: "collection", Target: "detail", Concurrency: 4, ItemTimeout: 20 * time.Millisecond, Failure: tc.failure}
+
+		begin := time.Now()
+		pr := runFanout(fConf, bConf, newFanoutSource(4), next)
+		// 항목 단위 Timeout이 Backend Timeout 보다 우선
+		if elapsed := time.Since(begin); elapsed > 500*time.Millisecond {
+			t.Errorf("%s: item_timeout should be applied, but %s", tc.failure, elapsed)
+		}
+
+		if tc.failed {
+			if pr.Error != context.DeadlineExceeded || pr.Response != nil {
+				t.Errorf("%s: backend should fail: %+v", tc.failure, pr)
+			}
+			continue
+		}
+		if pr.Error != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.failure, pr.Error.Error())
+		}
+		if pr.Response.IsComplete != tc.complete {
+			t.Errorf("%s: unexpected complete: %v", tc.failure, pr.Response.IsComplete)
+		}
+		// 실패한 항목은 원본 유지
+		items, _ := lookupItems(pr.Response.Data, "collection")
+		for i, item := range items {
+			expected := map[string]interface{}{"id": float64(i)}
+			if i%2 == 0 {
+				expected["detail"] = map[string]interface{}{"ok": true}
+			}
+			if !reflect.DeepEqual(item, expected) {
+				t.Errorf("%s: unexpected item[%d]: %v", tc.failure, i, item)
+			}
+		}
+	}
+}
+
+func TestRequestFanout_source(t *testing.T) {
+	next := func(_ context.Context, _ *Request) (*Response, error) {
+		return nil, errors.New("should not be called")
+	}
+	fConf := &fanoutConfig{Path: "collection", Concurrency: 1, Failure: fanoutFailurePartial}
+
+	if pr := runFanout(fConf, &config.BackendConfig{}, nil, next); pr.Error != errDependencyFailed {
+		t.Errorf("failed source should fail the fan-out: %v", pr.Error)
+	}
+	src := &Response{Data: map[string]interface{}{"items": []interface{}{}}}
+	if pr := runFanout(fConf, &config.BackendConfig{}, src, next); pr.Error != errFanoutNoCollection {
+		t.Errorf("missing collection should fail the fan-out: %v", pr.Error)
+	}
+}
+
+func TestNewMergeDataChain_fanoutReplacesItems(t *testing.T) {
+	for _, combiner := range []string{defaultCombinerName, DeepMergeCombinerName, FirstWinsCombinerName} {
+		for _, mode := range []string{sequentialKey, dagKey} {
+			eConf := &config.EndpointConfig{
+				Endpoint:   "/fanout",
+				Timeout:    time.Second,
+				Middleware: newProxyMiddleware(config.MWConfig{mode: true, combinerKey: combiner}),
+				Backend: []*config.BackendConfig{
+					{URLPattern: "/items"},
+					{URLPattern: "/items/{{.Resp0_id}}", Middleware: newProxyMiddleware(config.MWConfig{fanoutKey: map[string]interface{}{"source": 0, "target": "detail"}})},
+				},
+			}
+			source := func(_ context.Context, _ *Request) (*Response, error) {
+				return newFanoutSource(2), nil
+			}
+			detail := func(_ context.Context, req *Request) (*Response, error) {
+				return &Response{Data: map[string]interface{}{"name": "item-" + req.Params["Resp0_id"]}, IsComplete: true}, nil
+			}
+
+			res, err := NewMergeDataChain(eConf)(source, detail)(context.Background(), &Request{Params: map[string]string{}})
+			if err != nil {
+				t.Fatalf("%s/%s: unexpected error: %s", combiner, mode, err.Error())
+			}
+			expected := map[string]interface{}{"collection": []interface{}{
+				map[string]interface{}{"id": float64(0), "detail": map[string]interface{}{"name": "item-0"}},
+				map[string]interface{}{"id": float64(1), "detail": map[string]interface{}{"name": "item-1"}},
+			}}
+			if !res.IsComplete || !reflect.DeepEqual(res.Data, expected) {
+				t.Errorf("%s/%s: fan-out items should replace the source items: %v", combiner, mode, res.Data)
+			}
+		}
+	}
+}
@@ -2,6 +2,8 @@
 package proxy
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
@@ -13,6 +15,9 @@ import (
 
 const (
 	flatmapFilter = "flatmap_filter"
+
+	// flatten 처리의 기본 키 구분자
+	defaultFlatmapSeparator = "_"
 )
 
 // ===== [ Types ] =====
@@ -28,6 +33,10 @@ type flatmapFormatter struct {
 type flatmapOp struct {
 	Type string     `yaml:"type"`
 	Args [][]string `yarml:"args"`
+	// set 처리에 사용할 값
+	Value interface{} `yaml:"value"`
+	// flatten 처리에 사용할 키 구분자
+	Separator string `yaml:"separator"`
 }
 
 // propertyFilter - Response Filtering에 사용할 함수 정의
@@ -108,7 +117,28 @@ func (ff flatmapFormatter) processOps(entity *Response) {
 			for _, val := range op.Args {
 				flatten.Del(val)
 			}
-
+		case "copy":
+			// copy - 원본을 유지하고 지정한 경로에 복사
+			flatten.Copy(op.Args[0], op.Args[1])
+		case "set":
+			// set - 지정한 경로에 고정 값 설정
+			flatten.Set(op.Args[0], op.Value)
+		case "append":
+			// append - 여러 경로의 배열을 연결해서 첫번째 경로에 설정
+			merged := []interface{}{}
+			for _, src := range op.Args[1:] {
+				merged = appendValues(merged, flatten.Get(src), hasWildcard(src))
+			}
+			flatten.Set(op.Args[0], merged)
+		case "rename":
+			// rename - 지정한 경로 (wildcard 포함)의 마지막 필드명 변경
+			flatten.Rename(op.Args[0], strings.Join(op.Args[1], "."))
+		case "flatten":
+			// flatten - 중첩 객체는 단일 레벨 객체로, 중첩 배열은 단일 배열로 변환
+			flatten.Apply(op.Args[0], flattenValue(op.Separator))
+		case "cast":
+			// cast - 지정한 경로의 값을 지정한 형식으로 변환
+			flatten.Apply(op.Args[0], castValue(strings.Join(op.Args[1], ".")))
 		default:
 		}
 	}
@@ -127,8 +157,17 @@ func whitelistPrune(wlDict map[string]interface{}, inDict map[string]interface{}
 		deleteSibling = true
 		if subWl, ok := wlDict[k]; ok {
 			if subWlDict, okk := subWl.(map[string]interface{}); okk {
-				if subInDict, isDict := v.(map[string]interface{}); isDict && !whitelistPrune(subWlDict, subInDict) {
-					deleteSibling = false
+				switch sub := v.(type) {
+				case map[string]interface{}:
+					if !whitelistPrune(subWlDict, sub) {
+						deleteSibling = false
+					}
+				case []interface{}:
+					// 객체 배열은 모든 항목을 대상으로 처리
+					if kept := whitelistPruneArray(subWlDict, sub); len(kept) > 0 {
+						inDict[k] = kept
+						deleteSibling = false
+					}
 				}
 			} else {
 				// whitelist leaf, maintain this branch
@@ -144,6 +183,24 @@ func whitelistPrune(wlDict map[string]interface{}, inDict map[string]interface{}
 	return canDelete
 }
 
+// whitelistPruneArray - 지정한 Whitelist 맵으로 배열의 모든 항목에서 데이터 추출 (추출된 데이터가 없는 항목은 제거)
+func whitelistPruneArray(wlDict map[string]interface{}, in []interface{}) []interface{} {
+	kept := in[:0]
+	for _, e := range in {
+		switch t := e.(type) {
+		case map[string]interface{}:
+			if !whitelistPrune(wlDict, t) {
+				kept = append(kept, t)
+			}
+		case []interface{}:
+			if sub := whitelistPruneArray(wlDict, t); len(sub) > 0 {
+				kept = append(kept, sub)
+			}
+		}
+	}
+	return kept
+}
+
 // buildDictPath - 지정한 맵과 필드들의 정보를 이용해서 필드명 기준의 맵 생성
 func buildDictPath(accumulator map[string]interface{}, fields []string) map[string]interface{} {
 	ok := true
@@ -167,9 +224,20 @@ func buildDictPath(accumulator map[string]interface{}, fields []string) map[stri
 
 // newWhitelistFilter - 지정한 Whitelist를 Response에서 추출하기 위한 Filter 생성
 func newWhitelistFilter(whitelist []string) propertyFilter {
+	paths := make([][]string, len(whitelist))
+	extended := false
+	for i, k := range whitelist {
+		paths[i] = strings.Split(k, ".")
+		extended = extended || isExtendedPath(paths[i])
+	}
+	if extended {
+		return newTreeFilter(func(t *tree.Tree) {
+			t.Keep(paths...)
+		})
+	}
+
 	wlDict := make(map[string]interface{})
-	for _, k := range whitelist {
-		wlFields := strings.Split(k, ".")
+	for _, wlFields := range paths {
 		d := buildDictPath(wlDict, wlFields[:len(wlFields)-1])
 		d[wlFields[len(wlFields)-1]] = true
 	}
@@ -185,43 +253,74 @@ func newWhitelistFilter(whitelist []string) propertyFilter {
 
 // newBlacklistFilter - 지정한 Blacklist 를 Response에서 제거하기 위한 Filter 생성
 func newBlacklistFilter(blacklist []string) propertyFilter {
-	bl := make(map[string][]string, len(blacklist))
+	plains := [][]string{}
+	extended := [][]string{}
 	for _, key := range blacklist {
-		keys := strings.Split(key, ".")
-		if len(keys) > 1 {
-			if sub, ok := bl[keys[0]]; ok {
-				bl[keys[0]] = append(sub, keys[1])
-			} else {
-				bl[keys[0]] = []string{keys[1]}
-			}
+		if path := strings.Split(key, "."); isExtendedPath(path) {
+			extended = append(extended, path)
 		} else {
-			bl[keys[0]] = []string{}
+			plains = append(plains, path)
 		}
 	}
 
+	treeFilter := newTreeFilter(func(t *tree.Tree) {
+		for _, p := range extended {
+			t.Del(p)
+		}
+	})
+
 	return func(entity *Response) {
-		for k, sub := range bl {
-			if len(sub) == 0 {
-				delete(entity.Data, k)
-			} else {
-				if tmp := blacklistFilterSub(entity.Data[k], sub); len(tmp) > 0 {
-					entity.Data[k] = tmp
-				}
-			}
+		for _, path := range plains {
+			blacklistDelete(entity.Data, path)
+		}
+		if len(extended) > 0 {
+			treeFilter(entity)
 		}
 	}
 }
 
-// blacklistFilterSub - 지정한 Value Map에서 지정한 Blacklist 지정 데이터를 제거
-func blacklistFilterSub(vMap interface{}, blacklist []string) map[string]interface{} {
-	tmp, ok := vMap.(map[string]interface{})
-	if !ok {
-		return map[string]interface{}{}
+// blacklistDelete - 지정한 값에서 경로에 해당하는 필드를 제거 (경로 중간의 객체 배열은 모든 항목을 대상으로 처리)
+func blacklistDelete(v interface{}, path []string) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(t, path[0])
+			return
+		}
+		if sub, ok := t[path[0]]; ok {
+			blacklistDelete(sub, path[1:])
+		}
+	case []interface{}:
+		for _, e := range t {
+			blacklistDelete(e, path)
+		}
 	}
-	for _, key := range blacklist {
-		delete(tmp, key)
+}
+
+// isExtendedPath - 지정한 경로에 배열 Index 또는 Wildcard ("*", "**")가 사용되었는지 검증
+func isExtendedPath(path []string) bool {
+	for _, p := range path {
+		if _, err := strconv.Atoi(p); err == nil || p == "*" || p == "**" {
+			return true
+		}
+	}
+	return false
+}
+
+// newTreeFilter - Response 데이터를 Flatmap Tree로 구성해서 지정한 함수로 처리하는 Filter 생성
+func newTreeFilter(fn func(*tree.Tree)) propertyFilter {
+	return func(entity *Response) {
+		t, err := tree.New(entity.Data)
+		if err != nil {
+			return
+		}
+		fn(t)
+		if data, ok := t.Get([]string{}).(map[string]interface{}); ok {
+			entity.Data = data
+		} else {
+			entity.Data = map[string]interface{}{}
+		}
 	}
-	return tmp
 }
 
 // newFlatmapFormatter - 지정된 BackendConfig 기준으로 Flatmap을 활용하는 Formatter 생성
@@ -252,6 +351,14 @@ func newFlatmapFormatter(bConf *config.BackendConfig) EntityFormatter {
 							}
 						}
 					}
+					if len(op.Args) < config.FlatmapOps.MinArgs(op.Type) {
+						// 설정 검증을 거치지 않은 경우의 인자 부족은 무시
+						continue
+					}
+					op.Value = m["value"]
+					if sep, ok := m["separator"].(string); ok {
+						op.Separator = sep
+					}
 					ops = append(ops, op)
 				}
 				if len(ops) == 0 {
@@ -269,6 +376,108 @@ func newFlatmapFormatter(bConf *config.BackendConfig) EntityFormatter {
 	return nil
 }
 
+// hasWildcard - 지정한 경로에 wildcard ("*", "**") 가 존재하는지 검증
+func hasWildcard(path []string) bool {
+	for _, p := range path {
+		if p == "*" || p == "**" {
+			return true
+		}
+	}
+	return false
+}
+
+// appendValues - 지정한 값 (배열인 경우는 항목들)을 배열에 추가 (wildcard 결과는 항목 배열도 펼쳐서 추가)
+func appendValues(dst []interface{}, v interface{}, spread bool) []interface{} {
+	arr, ok := v.([]interface{})
+	if !ok {
+		if v != nil {
+			dst = append(dst, v)
+		}
+		return dst
+	}
+	for _, e := range arr {
+		if spread {
+			dst = appendValues(dst, e, false)
+		} else if e != nil {
+			dst = append(dst, e)
+		}
+	}
+	return dst
+}
+
+// flattenMap - 중첩 객체의 필드들을 구분자로 연결한 키로 지정한 맵에 설정
+func flattenMap(dst map[string]interface{}, prefix, sep string, src map[string]interface{}) {
+	for k, v := range src {
+		key := k
+		if prefix != "" {
+			key = prefix + sep + k
+		}
+		if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+			flattenMap(dst, key, sep, m)
+			continue
+		}
+		dst[key] = v
+	}
+}
+
+// flattenValue - 객체는 단일 레벨 객체로, 중첩 배열은 단일 배열로 변환하는 함수 반환 (그 외는 변경 없음)
+func flattenValue(sep string) func(interface{}) interface{} {
+	if sep == "" {
+		sep = defaultFlatmapSeparator
+	}
+	return func(v interface{}) interface{} {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			res := map[string]interface{}{}
+			flattenMap(res, "", sep, t)
+			return res
+		case []interface{}:
+			res := []interface{}{}
+			for _, e := range t {
+				if arr, ok := e.([]interface{}); ok {
+					res = append(res, arr...)
+				} else {
+					res = append(res, e)
+				}
+			}
+			return res
+		}
+		return nil
+	}
+}
+
+// castValue - 단일 값을 지정한 형식 ("string", "number", "int", "bool")으로 변환하는 함수 반환 (변환 불가인 경우는 변경 없음)
+func castValue(t string) func(interface{}) interface{} {
+	return func(v interface{}) interface{} {
+		switch v.(type) {
+		case map[string]interface{}, []interface{}, nil:
+			return nil
+		}
+
+		s := fmt.Sprintf("%v", v)
+		switch t {
+		case "string":
+			return s
+		case "number":
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return f
+			}
+		case "int":
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return int64(f)
+			}
+		case "bool":
+			if b, err := strconv.ParseBool(s); err == nil {
+				return b
+			}
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return f != 0
+			}
+		}
+		return nil
+	}
+}
+
 // extractTarget - 지정한 Response에 대해 지정한 Target이 존재하는지를 검증하고 반환 (단, Map 형식이어야 하며, 만일 없거나, 변환 불가이면 빈 데이터로 처리)
 func extractTarget(target string, entity *Response) {
 	for _, part := range strings.Split(target, ".") {
@@ -290,6 +499,9 @@ func extractTarget(target string, entity *Response) {
 
 // NewEntityFormatter - 지정된 Backend 설정을 기준으로 Response 처리에 사용할 EntityFormatter 생성
 func NewEntityFormatter(bConf *config.BackendConfig) EntityFormatter {
+	if qf := newQueryFormatter(bConf); qf != nil {
+		return qf
+	}
 	if ff := newFlatmapFormatter(bConf); ff != nil {
 		return ff
 	}
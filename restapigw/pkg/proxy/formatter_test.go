@@ -0,0 +1,195 @@
+package proxy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+)
+
+// formatterSample - 테스트 응답 데이터 생성 (Formatter가 데이터를 변경하므로 매번 생성)
+func formatterSample() map[string]interface{} {
+	return map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{"c": 1, "d": 2},
+			"e": 3,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": 1, "name": "x", "tags": []interface{}{"t1", "t2"}, "meta": map[string]interface{}{"id": 10, "secret": "s1"}},
+			map[string]interface{}{"id": 2, "name": "y", "tags": []interface{}{"t3"}, "meta": map[string]interface{}{"id": 20, "secret": "s2"}},
+			map[string]interface{}{"id": 3, "name": "z", "tags": []interface{}{}, "meta": map[string]interface{}{"id": 30, "secret": "s3"}},
+		},
+		"count": "3",
+		"ok":    "true",
+	}
+}
+
+// assertJSON - 지정한 데이터를 JSON 기준으로 비교
+func assertJSON(t *testing.T, name string, actual interface{}, expected string) {
+	t.Helper()
+	raw, _ := json.Marshal(actual)
+	var got, want interface{}
+	json.Unmarshal(raw, &got)
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatalf("%s: invalid expected json: %s", name, err.Error())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s: unexpected result\n\tactual:   %s\n\texpected: %s", name, raw, expected)
+	}
+}
+
+// newFlatmapBackend - 지정한 flatmap_filter 처리들을 설정한 Backend 설정 생성
+func newFlatmapBackend(ops ...config.MWConfig) *config.BackendConfig {
+	vs := make([]interface{}, len(ops))
+	for i, op := range ops {
+		vs[i] = op
+	}
+	return &config.BackendConfig{Middleware: config.MWConfig{MWNamespace: config.MWConfig{flatmapFilter: vs}}}
+}
+
+func flatmapOpConf(opType string, args ...interface{}) config.MWConfig {
+	return config.MWConfig{"type": opType, "args": args}
+}
+
+func TestFlatmapFormatter_ops(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		ops      []config.MWConfig
+		expected string
+	}{
+		{
+			name:     "move and del",
+			ops:      []config.MWConfig{flatmapOpConf("move", "a.b.c", "c"), flatmapOpConf("del", "a", "items", "count", "ok")},
+			expected: `{"c": 1}`,
+		},
+		{
+			name:     "copy",
+			ops:      []config.MWConfig{flatmapOpConf("copy", "items.*.meta.id", "items.*.metaId"), flatmapOpConf("del", "a", "items.*.meta", "items.*.tags", "items.*.name", "count", "ok")},
+			expected: `{"items": [{"id": 1, "metaId": 10}, {"id": 2, "metaId": 20}, {"id": 3, "metaId": 30}]}`,
+		},
+		{
+			name:     "set",
+			ops:      []config.MWConfig{{"type": "set", "args": []interface{}{"a.b"}, "value": "v"}, {"type": "set", "args": []interface{}{"items.-1.name"}, "value": "last"}, flatmapOpConf("del", "items.*.meta", "items.*.tags", "items.*.id", "count", "ok")},
+			expected: `{"a": {"b": "v", "e": 3}, "items": [{"name": "x"}, {"name": "y"}, {"name": "last"}]}`,
+		},
+		{
+			name:     "append",
+			ops:      []config.MWConfig{flatmapOpConf("append", "allTags", "items.*.tags", "items.0.name"), flatmapOpConf("del", "a", "items", "count", "ok")},
+			expected: `{"allTags": ["t1", "t2", "t3", "x"]}`,
+		},
+		{
+			name:     "rename",
+			ops:      []config.MWConfig{flatmapOpConf("rename", "items.*.name", "title"), flatmapOpConf("del", "a", "items.*.meta", "items.*.tags", "items.*.id", "count", "ok")},
+			expected: `{"items": [{"title": "x"}, {"title": "y"}, {"title": "z"}]}`,
+		},
+		{
+			name:     "flatten object",
+			ops:      []config.MWConfig{flatmapOpConf("flatten", "a"), flatmapOpConf("del", "items", "count", "ok")},
+			expected: `{"a": {"b_c": 1, "b_d": 2, "e": 3}}`,
+		},
+		{
+			name:     "flatten separator",
+			ops:      []config.MWConfig{{"type": "flatten", "args": []interface{}{"a"}, "separator": "."}, flatmapOpConf("del", "items", "count", "ok")},
+			expected: `{"a": {"b.c": 1, "b.d": 2, "e": 3}}`,
+		},
+		{
+			name:     "flatten array",
+			ops:      []config.MWConfig{flatmapOpConf("append", "tags", "items.*.tags"), flatmapOpConf("del", "a", "items", "count", "ok"), {"type": "set", "args": []interface{}{"nested"}, "value": []interface{}{[]interface{}{1, 2}, 3}}, flatmapOpConf("flatten", "nested")},
+			expected: `{"tags": ["t1", "t2", "t3"], "nested": [1, 2, 3]}`,
+		},
+		{
+			name:     "cast",
+			ops:      []config.MWConfig{flatmapOpConf("cast", "count", "int"), flatmapOpConf("cast", "ok", "bool"), flatmapOpConf("cast", "items.*.id", "string"), flatmapOpConf("cast", "items.*.name", "number"), flatmapOpConf("del", "a", "items.*.meta", "items.*.tags")},
+			expected: `{"count": 3, "ok": true, "items": [{"id": "1", "name": "x"}, {"id": "2", "name": "y"}, {"id": "3", "name": "z"}]}`,
+		},
+	} {
+		ef := NewEntityFormatter(newFlatmapBackend(tc.ops...))
+		if _, ok := ef.(*flatmapFormatter); !ok {
+			t.Fatalf("%s: unexpected formatter %T", tc.name, ef)
+		}
+		res := ef.Format(Response{Data: formatterSample(), IsComplete: true})
+		assertJSON(t, tc.name, res.Data, tc.expected)
+	}
+}
+
+func TestFlatmapFormatter_invalidOps(t *testing.T) {
+	// 인자가 부족한 처리는 무시하고, 처리할 것이 없으면 Flatmap을 사용하지 않음
+	bConf := newFlatmapBackend(flatmapOpConf("move", "a"), flatmapOpConf("append", "x"))
+	if _, ok := NewEntityFormatter(bConf).(*flatmapFormatter); ok {
+		t.Error("flatmap formatter must not be used without valid operations")
+	}
+}
+
+// filterSample - Whitelist, Blacklist 비교용 응답 데이터 생성 (모든 Leaf 값은 고유)
+func filterSample() map[string]interface{} {
+	return map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{"c": "abc", "d": "abd"},
+			"e": "ae",
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": "i0", "name": "n0", "tags": []interface{}{"t00", "t01"}, "meta": map[string]interface{}{"id": "m0", "secret": "s0"}},
+			map[string]interface{}{"id": "i1", "name": "n1", "tags": []interface{}{"t10"}, "meta": map[string]interface{}{"id": "m1", "secret": "s1"}},
+			map[string]interface{}{"id": "i2", "name": "n2", "meta": map[string]interface{}{"id": "m2", "secret": "s2"}},
+		},
+		"secret": "s",
+		"count":  "3",
+	}
+}
+
+// collectLeaves - 지정한 데이터의 Leaf 값 별 건수 수집 (nil과 빈 객체, 배열은 제외)
+func collectLeaves(v interface{}, acc map[interface{}]int) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, c := range t {
+			collectLeaves(c, acc)
+		}
+	case []interface{}:
+		for _, c := range t {
+			collectLeaves(c, acc)
+		}
+	case nil:
+	default:
+		acc[t]++
+	}
+}
+
+func TestWhitelistBlacklist_complement(t *testing.T) {
+	expected := map[interface{}]int{}
+	collectLeaves(filterSample(), expected)
+
+	for _, tc := range []struct {
+		name  string
+		paths []string
+		kept  string
+	}{
+		{name: "nested object", paths: []string{"a.b.c", "count"}, kept: `{"a": {"b": {"c": "abc"}}, "count": "3"}`},
+		{name: "object array", paths: []string{"items.meta.secret"}, kept: `{"items": [{"meta": {"secret": "s0"}}, {"meta": {"secret": "s1"}}, {"meta": {"secret": "s2"}}]}`},
+		{name: "object array partial", paths: []string{"items.tags"}, kept: `{"items": [{"tags": ["t00", "t01"]}, {"tags": ["t10"]}]}`},
+		{name: "wildcard", paths: []string{"a.*", "items.*.id"}, kept: `{"a": {"b": {"c": "abc", "d": "abd"}, "e": "ae"}, "items": [{"id": "i0"}, {"id": "i1"}, {"id": "i2"}]}`},
+		{name: "deep wildcard", paths: []string{"**.secret"}, kept: `{"secret": "s", "items": [{"meta": {"secret": "s0"}}, {"meta": {"secret": "s1"}}, {"meta": {"secret": "s2"}}]}`},
+		{name: "index", paths: []string{"items.0.name", "items.-1.meta.id", "items.0.tags.-1"}, kept: `{"items": [{"name": "n0", "tags": ["t01"]}, {"meta": {"id": "m2"}}]}`},
+		{name: "out of range index", paths: []string{"items.5.name", "items.-4.name", "a.e"}, kept: `{"a": {"e": "ae"}}`},
+		{name: "mixed", paths: []string{"a.b.d", "items.*.meta.id", "secret"}, kept: `{"a": {"b": {"d": "abd"}}, "items": [{"meta": {"id": "m0"}}, {"meta": {"id": "m1"}}, {"meta": {"id": "m2"}}], "secret": "s"}`},
+	} {
+		wl := &Response{Data: filterSample()}
+		newWhitelistFilter(tc.paths)(wl)
+		assertJSON(t, tc.name+" (whitelist)", wl.Data, tc.kept)
+
+		bl := &Response{Data: filterSample()}
+		newBlacklistFilter(tc.paths)(bl)
+
+		// Whitelist와 Blacklist 결과는 중복 없이 원본의 모든 Leaf 값을 구성
+		actual := map[interface{}]int{}
+		collectLeaves(wl.Data, actual)
+		kept := len(actual)
+		collectLeaves(bl.Data, actual)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: whitelist and blacklist results are not complementary\n\tactual:   %v\n\texpected: %v", tc.name, actual, expected)
+		}
+		if kept == 0 || kept == len(expected) {
+			t.Errorf("%s: whitelist must keep a part of the sample, kept %d", tc.name, kept)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Package proxy - Backend Response Header 정보를 Endpoint 설정에 따라 전달/병합/제거 처리하는 패키지
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	responseHeadersKey = "response_headers"
+
+	// 모든 Backend의 Header 값을 Backend 설정 순서대로 추가 (기본값)
+	headerMergeAppend = "append"
+	// 먼저 Header를 반환한 Backend의 값 사용
+	headerMergeFirst = "first"
+	// 나중에 Header를 반환한 Backend의 값 사용
+	headerMergeLast = "last"
+)
+
+var (
+	// API G/W가 Response를 재 구성하므로 전달 설정과 무관하게 항상 제거되는 Header 목록
+	alwaysStripHeaders = []string{
+		"Connection",
+		"Content-Encoding",
+		"Content-Length",
+		"Content-Type",
+		"Keep-Alive",
+		"Proxy-Authenticate",
+		"Trailer",
+		"Transfer-Encoding",
+		"Upgrade",
+	}
+)
+
+// ===== [ Types ] =====
+
+// responseHeaderConfig - Backend Response Header 전달을 위한 설정 구조 (Endpoint 레벨 "mw-proxy.response_headers")
+type responseHeaderConfig struct {
+	// 클라이언트로 전달할 Header 명 목록 (마지막 "*" 는 접두어 일치, 예: "X-*")
+	Forward []string `yaml:"forward"`
+	// 전달 대상에서 제외할 Header 명 목록 (마지막 "*" 는 접두어 일치)
+	Strip []string `yaml:"strip"`
+	// 여러 Backend에서 동일한 Header를 반환한 경우의 기본 병합 방식 ("append", "first", "last", 기본값: "append")
+	Merge string `yaml:"merge"`
+	// Header 별 병합 방식
+	MergeHeaders map[string]string `yaml:"merge_headers"`
+}
+
+// ===== [ Implementations ] =====
+
+// allowed - 지정한 Header가 클라이언트로 전달 가능한지 검증
+func (hc *responseHeaderConfig) allowed(name string) bool {
+	if matchHeader(alwaysStripHeaders, name) || matchHeader(hc.Strip, name) {
+		return false
+	}
+	return matchHeader(hc.Forward, name)
+}
+
+// mergeMode - 지정한 Header의 병합 방식 반환
+func (hc *responseHeaderConfig) mergeMode(name string) string {
+	for k, v := range hc.MergeHeaders {
+		if http.CanonicalHeaderKey(k) == name {
+			return v
+		}
+	}
+	return hc.Merge
+}
+
+// ===== [ Private Functions ] =====
+
+// matchHeader - 지정한 Header 명이 Pattern 목록에 포함되는지 검증 (대소문자 무시, 마지막 "*" 는 접두어 일치)
+func matchHeader(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		if strings.HasSuffix(p, "*") {
+			if strings.HasPrefix(name, strings.TrimSuffix(p, "*")) {
+				return true
+			}
+		} else if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// responseHeaderSetting - 지정한 Endpoint 설정의 Middleware ("mw-proxy") 에서 response_headers 설정 값 추출
+func responseHeaderSetting(eConf *config.EndpointConfig) (interface{}, bool) {
+	v, ok := eConf.Middleware[MWNamespace]
+	if !ok {
+		return nil, false
+	}
+	e, ok := v.(config.MWConfig)
+	if !ok {
+		return nil, false
+	}
+	tmp, ok := e[responseHeadersKey]
+	return tmp, ok
+}
+
+// mockHeaderNames - 지정한 Endpoint 설정의 Mock Backend에 설정된 응답 Header 명 목록 반환
+func mockHeaderNames(eConf *config.EndpointConfig) []string {
+	names := []string{}
+	for _, bConf := range eConf.Backend {
+		if !bConf.IsMock() {
+			continue
+		}
+		for k := range bConf.Mock.Headers {
+			names = append(names, http.CanonicalHeaderKey(k))
+		}
+	}
+	return names
+}
+
+// parseResponseHeaderConfig - 지정한 Endpoint 설정에서 Response Header 전달 설정 추출 (미 지정 또는 잘못된 설정인 경우는 nil)
+// (Mock Backend에 설정된 Header는 별도 설정 없이 전달 대상에 포함하며, strip 설정으로 제외 가능)
+func parseResponseHeaderConfig(eConf *config.EndpointConfig) *responseHeaderConfig {
+	hc := new(responseHeaderConfig)
+	tmp, ok := responseHeaderSetting(eConf)
+	if ok {
+		buf := new(bytes.Buffer)
+		yaml.NewEncoder(buf).Encode(tmp)
+		if err := yaml.NewDecoder(buf).Decode(hc); err != nil {
+			logger.Warnf("[API G/W] Proxy > Invalid response headers config on %s: %s", eConf.Endpoint, err.Error())
+			return nil
+		}
+	}
+	hc.Forward = append(hc.Forward, mockHeaderNames(eConf)...)
+	if !ok && len(hc.Forward) == 0 {
+		return nil
+	}
+
+	switch hc.Merge {
+	case headerMergeFirst, headerMergeLast:
+	default:
+		hc.Merge = headerMergeAppend
+	}
+	return hc
+}
+
+// mergeResponseHeaders - 지정한 Backend 순서의 Header 정보들을 설정에 따라 전달 대상만 선택하고 병합 처리 (설정이 없는 경우는 nil)
+func mergeResponseHeaders(hc *responseHeaderConfig, headers []map[string][]string) map[string][]string {
+	if hc == nil {
+		return nil
+	}
+
+	result := map[string][]string{}
+	for _, h := range headers {
+		for k, vs := range h {
+			name := http.CanonicalHeaderKey(k)
+			if len(vs) == 0 || !hc.allowed(name) {
+				continue
+			}
+
+			former, exists := result[name]
+			switch hc.mergeMode(name) {
+			case headerMergeFirst:
+				if exists {
+					continue
+				}
+				result[name] = append([]string{}, vs...)
+			case headerMergeLast:
+				result[name] = append([]string{}, vs...)
+			default:
+				result[name] = append(former, vs...)
+			}
+		}
+	}
+	return result
+}
+
+// ===== [ Public Functions ] =====
+
+// NewResponseHeaderChain - 단일 Backend Endpoint 설정의 Response Header 전달 규칙을 적용하는 Proxy Call chain 생성
+func NewResponseHeaderChain(eConf *config.EndpointConfig) CallChain {
+	hc := parseResponseHeaderConfig(eConf)
+	return func(next ...Proxy) Proxy {
+		if len(next) > 1 {
+			panic(ErrTooManyProxies)
+		}
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			res, err := next[0](ctx, req)
+			if res != nil && res.Io == nil {
+				res.Metadata.Headers = mergeResponseHeaders(hc, []map[string][]string{res.Metadata.Headers})
+			}
+			return res, err
+		}
+	}
+}
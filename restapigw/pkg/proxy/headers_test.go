@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/encoding"
+)
+
+func newMockEndpoint(mw config.MWConfig) *config.EndpointConfig {
+	bConf := &config.BackendConfig{
+		Method:   http.MethodGet,
+		Encoding: encoding.STRING,
+		Mock: &config.MockConfig{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]string{"X-Mock": "true", "X-Internal-Id": "42"},
+			Body:       "mocked",
+		},
+	}
+	bConf.Decoder = encoding.Get(encoding.STRING)(false, false)
+	return &config.EndpointConfig{Endpoint: "/mock", Method: http.MethodGet, Backend: []*config.BackendConfig{bConf}, Middleware: mw}
+}
+
+func TestResponseHeaderChain_mockHeaders(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		mw       config.MWConfig
+		expected map[string]string
+	}{
+		{
+			name:     "without response_headers",
+			mw:       config.MWConfig{},
+			expected: map[string]string{"X-Mock": "true", "X-Internal-Id": "42"},
+		},
+		{
+			name: "with strip",
+			mw: config.MWConfig{MWNamespace: config.MWConfig{
+				responseHeadersKey: map[string]interface{}{"strip": []interface{}{"X-Internal-*"}},
+			}},
+			expected: map[string]string{"X-Mock": "true"},
+		},
+	} {
+		eConf := newMockEndpoint(tc.mw)
+		backend := NewMockBackendFactory(func(*config.BackendConfig) Proxy { return DummyProxy })(eConf.Backend[0])
+		p := NewResponseHeaderChain(eConf)(backend)
+
+		res, err := p(context.Background(), &Request{Method: http.MethodGet, Path: "/mock", Headers: map[string][]string{}})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err.Error())
+		}
+		if len(res.Metadata.Headers) != len(tc.expected) {
+			t.Errorf("%s: unexpected headers: %v", tc.name, res.Metadata.Headers)
+		}
+		for k, v := range tc.expected {
+			if vs := res.Metadata.Headers[k]; len(vs) != 1 || vs[0] != v {
+				t.Errorf("%s: unexpected header %s: %v", tc.name, k, vs)
+			}
+		}
+	}
+}
+
+func TestResponseHeaderChain_withoutConfig(t *testing.T) {
+	eConf := &config.EndpointConfig{Endpoint: "/plain", Backend: []*config.BackendConfig{{URLPattern: "/plain"}}}
+	backend := func(_ context.Context, _ *Request) (*Response, error) {
+		return &Response{Data: map[string]interface{}{}, Metadata: Metadata{Headers: map[string][]string{"X-Backend": {"1"}}}}, nil
+	}
+
+	res, _ := NewResponseHeaderChain(eConf)(backend)(context.Background(), &Request{})
+	if res.Metadata.Headers != nil {
+		t.Errorf("backend headers must not be forwarded without response_headers: %v", res.Metadata.Headers)
+	}
+}
@@ -0,0 +1,249 @@
+// Package proxy - 응답이 지연되는 요청을 다른 Host로 추가 전달하고 먼저 성공한 응답을 사용하는 Hedged Request 처리 패키지
+package proxy
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/sd"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// Percentile 산정에 사용할 최근 응답 시간 수
+	hedgeSampleSize = 128
+	// Percentile 산정을 시작할 최소 응답 시간 수
+	hedgeMinSamples = 20
+	// Percentile 재 산정 주기 (응답 시간 수집 건수)
+	hedgeRecalcInterval = 16
+	// 추가 요청을 위해 기존과 다른 Host를 선택할 최대 횟수
+	hedgeSelectRetries = 3
+)
+
+// ===== [ Types ] =====
+
+type (
+	// hedgeResult - Hedged Request의 개별 요청 처리 결과 구조
+	hedgeResult struct {
+		host     string
+		done     func(sd.HostResult)
+		ctx      context.Context
+		res      *Response
+		err      error
+		duration time.Duration
+	}
+
+	// hedger - Backend 별 추가 요청 대기 시간 산정 및 추가 요청 비율 관리 구조
+	hedger struct {
+		conf     *config.HedgeConfig
+		requests uint64
+		hedges   uint64
+		delay    int64
+
+		mu      sync.Mutex
+		samples []time.Duration
+		next    int
+		count   int
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// succeeded - 요청 처리 결과가 성공 (5xx, Network 오류가 아닌 응답) 인지 검증
+func (hr *hedgeResult) succeeded() bool {
+	return hostResult(hr.ctx, hr.res, hr.err) == sd.HostSucceeded
+}
+
+// currentDelay - 추가 요청 전에 대기할 시간 반환
+func (h *hedger) currentDelay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&h.delay))
+}
+
+// observe - 성공한 응답 시간을 수집하고 주기적으로 Percentile 기준 대기 시간 재 산정
+func (h *hedger) observe(d time.Duration) {
+	if h.conf.Percentile <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) < hedgeSampleSize {
+		h.samples = append(h.samples, d)
+	} else {
+		h.samples[h.next] = d
+	}
+	h.next = (h.next + 1) % hedgeSampleSize
+	h.count++
+
+	if h.count < hedgeMinSamples || h.count%hedgeRecalcInterval != 0 {
+		return
+	}
+	sorted := make([]time.Duration, len(h.samples))
+	copy(sorted, h.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(float64(len(sorted)-1) * h.conf.Percentile / 100)
+	atomic.StoreInt64(&h.delay, int64(sorted[idx]))
+}
+
+// acquire - 추가 요청 비율이 최대 값을 넘지 않는 경우 추가 요청 허용
+func (h *hedger) acquire() bool {
+	requests := atomic.LoadUint64(&h.requests)
+	if float64(atomic.LoadUint64(&h.hedges)+1) > h.conf.MaxRatio*float64(requests) {
+		return false
+	}
+	atomic.AddUint64(&h.hedges, 1)
+	return true
+}
+
+// ===== [ Private Functions ] =====
+
+// alternateHost - 지정한 Host와 다른 Host와 호출 결과 전달 함수를 Balancer로 선택 (선택할 수 없는 경우는 false)
+func alternateHost(sb sd.Balancer, primary string) (string, func(sd.HostResult), bool) {
+	for i := 0; i < hedgeSelectRetries; i++ {
+		host, done, err := selectHost(sb)
+		if err != nil {
+			return "", nil, false
+		}
+		if host != primary {
+			return host, done, true
+		}
+		// 선택으로 사용된 Circuit Breaker 시험 호출 정리
+		done(sd.HostIgnored)
+	}
+	return "", nil, false
+}
+
+// hedgeRequest - 지정한 Host로 전달할 추가 요청 구성
+func hedgeRequest(req *Request, host string) (*Request, error) {
+	r := CloneRequest(req)
+	u, err := url.Parse(host + r.Path)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = req.URL.RawQuery
+	r.URL = u
+	return r, nil
+}
+
+// ===== [ Public Functions ] =====
+
+// NewHedgedChain - Backend의 Hedge 설정에 따라 지연되는 GET 요청을 다른 Host로 추가 전달하고 먼저 성공한 응답을 반환하는 Proxy 호출 체인 생성
+// (Load Balancer와 Backend Proxy 사이에 구성되며, 나머지 요청은 취소하고 Host 별 결과는 Circuit Breaker로 전달)
+func NewHedgedChain(bConf *config.BackendConfig, sb sd.Balancer) CallChain {
+	return func(next ...Proxy) Proxy {
+		if len(next) > 1 {
+			panic(ErrTooManyProxies)
+		}
+		if bConf.Hedge == nil {
+			return next[0]
+		}
+
+		h := &hedger{conf: bConf.Hedge, delay: int64(bConf.Hedge.Delay)}
+		maxSize := maxBodySize(bConf)
+		report := func(r *hedgeResult) {
+			r.done(hostResult(r.ctx, r.res, r.err))
+		}
+
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			sh, ok := ctx.Value(selectedHostKey{}).(*selectedHost)
+			if !ok || req.URL == nil || strings.ToUpper(req.Method) != "GET" {
+				return next[0](ctx, req)
+			}
+			atomic.AddUint64(&h.requests, 1)
+
+			// 추가 요청에도 Body를 전송할 수 있도록 보관
+			if ok, err := bufferRequestBody(req, maxSize); err != nil {
+				return nil, err
+			} else if !ok {
+				return next[0](ctx, req)
+			}
+
+			results := make(chan *hedgeResult, 2)
+			cancels := map[string]context.CancelFunc{}
+			// 응답 Body를 읽어야 하는 요청 (Streaming 등) 은 처리가 끝날 때까지 Context 유지 (Endpoint 처리 종료시 취소)
+			keep := ""
+			defer func() {
+				for host, cancel := range cancels {
+					if host != keep {
+						cancel()
+					}
+				}
+			}()
+			launch := func(host string, done func(sd.HostResult), r *Request) {
+				localCtx, cancel := context.WithCancel(ctx)
+				cancels[host] = cancel
+				go func() {
+					begin := time.Now()
+					res, err := next[0](localCtx, r)
+					results <- &hedgeResult{host: host, done: done, ctx: localCtx, res: res, err: err, duration: time.Since(begin)}
+				}()
+			}
+			launch(sh.host, sh.done, req)
+
+			delay := h.currentDelay()
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+
+			pending := 1
+			var failed *hedgeResult
+			for {
+				select {
+				case <-timer.C:
+					if !h.acquire() {
+						continue
+					}
+					alt, done, ok := alternateHost(sb, sh.host)
+					if !ok {
+						continue
+					}
+					r, err := hedgeRequest(req, alt)
+					if err != nil {
+						done(sd.HostIgnored)
+						continue
+					}
+					logger.Debugf("[CallChain] Hedge > %s no response in %s, hedging to %s", bConf.URLPattern, delay, alt)
+					launch(alt, done, r)
+					pending++
+
+				case r := <-results:
+					pending--
+					// 성공했거나 (추가 요청 전에 실패한 경우 포함) 더 이상 대기할 요청이 없는 경우 결과 반환
+					if r.succeeded() || pending == 0 {
+						// 나머지 요청은 반환시 취소하고 결과는 정리
+						if r.res != nil && r.res.Io != nil {
+							keep = r.host
+						}
+						if pending > 0 {
+							go func() {
+								lr := <-results
+								discardResponse(lr.res)
+								report(lr)
+							}()
+						}
+						if failed != nil {
+							report(failed)
+						}
+						if r.succeeded() {
+							h.observe(r.duration)
+						}
+
+						// 응답한 Host의 결과는 Load Balancer가 전달
+						sh.host, sh.done = r.host, r.done
+						return r.res, r.err
+					}
+
+					// 먼저 실패한 요청은 보관하고 다른 요청의 결과 대기
+					failed = r
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/sd"
+)
+
+// hedgeCall - Hedged Request 처리 중 Backend 호출 정보
+type hedgeCall struct {
+	host  string
+	ctx   context.Context
+	begin time.Time
+}
+
+// hedgeBackend - 첫번째 호출은 slow 만큼 지연 (Context 종료시 중단), 이후 호출은 즉시 응답하는 Backend와 호출 정보 목록 반환
+func hedgeBackend(slow time.Duration, res func() *Response) (Proxy, func() []hedgeCall) {
+	var mu sync.Mutex
+	var calls []hedgeCall
+	p := func(ctx context.Context, req *Request) (*Response, error) {
+		mu.Lock()
+		calls = append(calls, hedgeCall{host: req.URL.Host, ctx: ctx, begin: time.Now()})
+		first := len(calls) == 1
+		mu.Unlock()
+
+		if first {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(slow):
+			}
+		}
+		return res(), nil
+	}
+	return p, func() []hedgeCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]hedgeCall{}, calls...)
+	}
+}
+
+func newHedgeStack(hedge *config.HedgeConfig, backend Proxy) Proxy {
+	bConf := &config.BackendConfig{
+		URLPattern:  "/hedge",
+		Hosts:       []*config.HostConfig{{Host: "http://a"}, {Host: "http://b"}},
+		BalanceMode: "rr",
+		Hedge:       hedge,
+	}
+	sb := sd.NewBalancer(sd.GetSubscriber(bConf))
+	return newLoadBalancedChain(sb)(NewHedgedChain(bConf, sb)(backend))
+}
+
+func okResponse() *Response {
+	return &Response{Metadata: Metadata{StatusCode: http.StatusOK}, IsComplete: true}
+}
+
+func TestHedgedChain_delay(t *testing.T) {
+	backend, calls := hedgeBackend(time.Second, okResponse)
+	p := newHedgeStack(&config.HedgeConfig{Delay: 30 * time.Millisecond, MaxRatio: 1}, backend)
+
+	begin := time.Now()
+	res, err := p(context.Background(), &Request{Method: http.MethodGet, Path: "/hedge"})
+	if err != nil || res.Metadata.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected result: %+v, %v", res, err)
+	}
+	if elapsed := time.Since(begin); elapsed > 500*time.Millisecond {
+		t.Fatalf("hedged response should be returned, but %s", elapsed)
+	}
+
+	cs := calls()
+	if len(cs) != 2 {
+		t.Fatalf("unexpected calls: %d", len(cs))
+	}
+	if cs[0].host == cs[1].host {
+		t.Errorf("hedged request should be sent to another host: %s", cs[1].host)
+	}
+	if d := cs[1].begin.Sub(cs[0].begin); d < 30*time.Millisecond {
+		t.Errorf("hedged request should wait for the delay, but %s", d)
+	}
+
+	// 응답한 요청과 나머지 요청 모두 반환시 취소
+	for i, c := range cs {
+		select {
+		case <-c.ctx.Done():
+		case <-time.After(time.Second):
+			t.Errorf("call[%d] context should be canceled", i)
+		}
+	}
+}
+
+func TestHedgedChain_noHedge(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		method string
+		slow   time.Duration
+		ratio  float64
+	}{
+		{name: "fast primary", method: http.MethodGet, ratio: 1},
+		{name: "non GET", method: http.MethodPost, slow: 60 * time.Millisecond, ratio: 1},
+		{name: "max ratio", method: http.MethodGet, slow: 60 * time.Millisecond, ratio: 0.5},
+	} {
+		backend, calls := hedgeBackend(tc.slow, okResponse)
+		p := newHedgeStack(&config.HedgeConfig{Delay: 10 * time.Millisecond, MaxRatio: tc.ratio}, backend)
+		if _, err := p(context.Background(), &Request{Method: tc.method, Path: "/hedge"}); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err.Error())
+		}
+		if n := len(calls()); n != 1 {
+			t.Errorf("%s: request should not be hedged, but %d calls", tc.name, n)
+		}
+	}
+}
+
+func TestHedgedChain_keepStreamContext(t *testing.T) {
+	stream := func() *Response {
+		return &Response{Metadata: Metadata{StatusCode: http.StatusOK}, Io: strings.NewReader("stream")}
+	}
+	backend, calls := hedgeBackend(time.Second, stream)
+	p := newHedgeStack(&config.HedgeConfig{Delay: 10 * time.Millisecond, MaxRatio: 1}, backend)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := p(ctx, &Request{Method: http.MethodGet, Path: "/hedge"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	// 응답 Body를 읽어야 하는 요청은 Endpoint 처리가 끝날 때까지 유지
+	cs := calls()
+	<-cs[0].ctx.Done()
+	if cs[1].ctx.Err() != nil {
+		t.Error("winner context should be kept while the body is read")
+	}
+	cancel()
+	<-cs[1].ctx.Done()
+}
+
+func TestHedger_acquire(t *testing.T) {
+	h := &hedger{conf: &config.HedgeConfig{MaxRatio: 0.25}}
+
+	hedges := 0
+	for i := 0; i < 20; i++ {
+		h.requests++
+		if h.acquire() {
+			hedges++
+		}
+	}
+	if hedges != 5 {
+		t.Errorf("hedges should be limited to 25%% of requests, but %d", hedges)
+	}
+}
+
+func TestHedger_observe(t *testing.T) {
+	h := &hedger{conf: &config.HedgeConfig{Delay: time.Second, Percentile: 50}, delay: int64(time.Second)}
+
+	// 최소 수집 건수 전에는 설정된 대기 시간 사용
+	for i := 1; i < hedgeMinSamples; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+	if d := h.currentDelay(); d != time.Second {
+		t.Errorf("configured delay should be used before enough samples: %s", d)
+	}
+
+	for i := hedgeMinSamples; i <= 2*hedgeRecalcInterval; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+	if d := h.currentDelay(); d != 16*time.Millisecond {
+		t.Errorf("delay should be the 50th percentile: %s", d)
+	}
+}
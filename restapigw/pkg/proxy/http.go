@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
@@ -16,6 +17,11 @@ import (
 
 // ===== [ Constants and Variables ] =====
 
+const (
+	// RequestDeadlineHeaderName - Backend에 남은 처리 시간 (milliseconds)을 전달하기 위한 Header 명 (Backend의 propagate_deadline 설정시)
+	RequestDeadlineHeaderName = "X-Request-Deadline"
+)
+
 var (
 	logger = logging.NewLogger()
 )
@@ -33,6 +39,20 @@ type responseError interface {
 
 // ===== [ Private Functions ] =====
 
+// setRequestDeadline - 지정한 Context에 처리 기한이 있는 경우 남은 처리 시간을 milliseconds 단위로 Header에 설정 (없는 경우는 Client가 전달한 값 제거)
+func setRequestDeadline(ctx context.Context, h http.Header) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		h.Del(RequestDeadlineHeaderName)
+		return
+	}
+	remaining := time.Until(deadline) / time.Millisecond
+	if remaining < 0 {
+		remaining = 0
+	}
+	h.Set(RequestDeadlineHeaderName, strconv.FormatInt(int64(remaining), 10))
+}
+
 // ===== [ Public Functions ] =====
 
 // NewHTTPProxyWithHTTPExecutor - 지정된 BackendConfig 와 HTTP Request Executor와 응답 처리에 사용할 Decoder를 설정한 Proxy 반환
@@ -62,8 +82,15 @@ func NewHTTPProxyDetailed(bconf *config.BackendConfig, hre client.HTTPRequestExe
 			reqToBackend.Header[k] = tmp
 		}
 
+		// Backend 호출에 적용되는 남은 처리 시간 전달 (Host 간의 시간 차이에 영향받지 않도록 기한이 아닌 남은 시간으로 전달)
+		if bconf.PropagateDeadline {
+			setRequestDeadline(ctx, reqToBackend.Header)
+		}
+
 		// Body Size 정보 설정
-		if req.Body != nil {
+		if bb, ok := req.Body.(*bufferedBody); ok {
+			reqToBackend.ContentLength = int64(len(bb.data))
+		} else if req.Body != nil {
 			if v, ok := req.Headers["Content-Length"]; ok && len(v) == 1 && v[0] != "chunked" {
 				if size, err := strconv.Atoi(v[0]); err == nil {
 					reqToBackend.ContentLength = int64(size)
@@ -100,6 +127,15 @@ func NewHTTPProxyDetailed(bconf *config.BackendConfig, hre client.HTTPRequestExe
 			return nil, err
 		}
 
+		// Protocol 전환 (WebSocket 등의 Upgrade) 응답은 Backend 연결 (io.ReadWriteCloser) 을 그대로 반환
+		if resp != nil && resp.StatusCode == http.StatusSwitchingProtocols {
+			return &Response{
+				IsComplete: true,
+				Metadata:   Metadata{Headers: resp.Header, StatusCode: resp.StatusCode},
+				Io:         resp.Body,
+			}, nil
+		}
+
 		// Response Status 처리
 		resp, err = hsh(ctx, resp)
 		if err != nil {
@@ -155,6 +191,8 @@ func NewHTTPProxyDetailed(bconf *config.BackendConfig, hre client.HTTPRequestExe
 
 // NewRequestBuilderChain - Request 파라미터와 Backend Path를 설정한 Proxy 호출 체인을 생성한다.
 func NewRequestBuilderChain(bConf *config.BackendConfig) CallChain {
+	rf := NewRequestFormatter(bConf)
+	cv := NewClaimsVerifier(bConf.JWT)
 	return func(next ...Proxy) Proxy {
 		if len(next) > 1 {
 			panic(ErrTooManyProxies)
@@ -165,6 +203,13 @@ func NewRequestBuilderChain(bConf *config.BackendConfig) CallChain {
 			if !req.IsBypass {
 				r.GeneratePath(bConf.URLPattern)
 				r.Method = bConf.Method
+				r.ApplyTemplates(bConf)
+				r.ApplyRules(ctx, bConf, cv)
+				if rf != nil {
+					if err := rf.Format(&r); err != nil {
+						return nil, err
+					}
+				}
 			}
 
 			return next[0](ctx, &r)
@@ -3,6 +3,7 @@ package proxy
 
 import (
 	"context"
+	"io"
 	"net/http"
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/encoding"
@@ -46,11 +47,16 @@ func DefaultHTTPResponseParserFactory(conf HTTPResponseParserConfig) HTTPRespons
 		var data map[string]interface{}
 		err := conf.Decoder(resp.Body, &data)
 		resp.Body.Close()
+		if err == io.EOF {
+			// Body가 없는 정상 응답 (ex. 204 No Content) 은 빈 데이터로 처리
+			data, err = map[string]interface{}{}, nil
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		newResponse := Response{Data: data, IsComplete: true}
+		// Header 정보는 Endpoint의 Response Header 전달 설정에 따라 선택적으로 처리
+		newResponse := Response{Data: data, IsComplete: true, Metadata: Metadata{Headers: resp.Header}}
 		newResponse = conf.EntityFormatter.Format(newResponse)
 		return &newResponse, nil
 	}
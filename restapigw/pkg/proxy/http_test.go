@@ -0,0 +1,129 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/transport/http/client"
+)
+
+// captureExecutor - Backend로 전달되는 http.Request를 보관하고 빈 응답을 반환하는 Executor
+func captureExecutor(captured *http.Request) client.HTTPRequestExecutor {
+	return func(_ context.Context, req *http.Request) (*http.Response, error) {
+		*captured = *req
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	}
+}
+
+func TestNewHTTPProxy_propagateDeadline(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		propagate bool
+		timeout   time.Duration
+		client    string
+		expected  bool
+	}{
+		{name: "propagate disabled", timeout: time.Second},
+		{name: "propagate enabled", propagate: true, timeout: time.Second, expected: true},
+		{name: "without deadline", propagate: true},
+		{name: "client value replaced", propagate: true, timeout: time.Second, client: "600000", expected: true},
+		{name: "client value removed without deadline", propagate: true, client: "600000"},
+	} {
+		var captured http.Request
+		bConf := &config.BackendConfig{PropagateDeadline: tc.propagate}
+		p := NewHTTPProxyDetailed(bConf, captureExecutor(&captured), client.NoOpHTTPStatusHandler, NoOpHTTPResponseParser)
+
+		ctx := context.Background()
+		if tc.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+			defer cancel()
+		}
+		headers := map[string][]string{}
+		if tc.client != "" {
+			headers[RequestDeadlineHeaderName] = []string{tc.client}
+		}
+		u, _ := url.Parse("http://backend/deadline")
+		if _, err := p(ctx, &Request{Method: http.MethodGet, URL: u, Headers: headers}); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err.Error())
+		}
+
+		v := captured.Header.Get(RequestDeadlineHeaderName)
+		if !tc.expected {
+			if tc.propagate && v != "" {
+				t.Errorf("%s: deadline header should not be sent: %s", tc.name, v)
+			} else if !tc.propagate && v != tc.client {
+				t.Errorf("%s: header should be passed as is: %s", tc.name, v)
+			}
+			continue
+		}
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			t.Fatalf("%s: invalid deadline header: %s", tc.name, v)
+		}
+		if ms <= 0 || ms > tc.timeout.Nanoseconds()/int64(time.Millisecond) {
+			t.Errorf("%s: remaining milliseconds out of range: %d", tc.name, ms)
+		}
+	}
+}
+
+func TestNewHTTPProxy_upgrade(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "echo" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		brw.Flush()
+		io.Copy(conn, brw)
+	}))
+	defer backend.Close()
+
+	p := NewHTTPProxyDetailed(&config.BackendConfig{}, client.DefaultHTTPRequestExecutor(client.NewHTTPClient), client.DefaultHTTPStatusHandler, NoOpHTTPResponseParser)
+	u, _ := url.Parse(backend.URL + "/ws")
+	res, err := p(context.Background(), &Request{Method: http.MethodGet, URL: u, Headers: map[string][]string{"Connection": {"Upgrade"}, "Upgrade": {"echo"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	// Protocol 전환 응답은 상태 검증 없이 Backend 연결 그대로 반환
+	conn, ok := res.Io.(io.ReadWriteCloser)
+	if res.Metadata.StatusCode != http.StatusSwitchingProtocols || !ok {
+		t.Fatalf("backend connection should be returned: %+v", res)
+	}
+	defer conn.Close()
+	conn.Write([]byte("ping"))
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != "ping" {
+		t.Errorf("backend connection should be usable: %q, %v", buf, err)
+	}
+}
+
+func TestNewHTTPProxy_bufferedBodyLength(t *testing.T) {
+	var captured http.Request
+	p := NewHTTPProxyDetailed(&config.BackendConfig{}, captureExecutor(&captured), client.NoOpHTTPStatusHandler, NoOpHTTPResponseParser)
+
+	// 보관된 Body는 Content-Length Header와 무관하게 실제 크기 사용
+	u, _ := url.Parse("http://backend/replicate")
+	req := &Request{Method: http.MethodPost, URL: u, Headers: map[string][]string{"Content-Length": {"chunked"}}, Body: newBufferedBody([]byte(`{"name":"vm"}`))}
+	if _, err := p(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if captured.ContentLength != int64(len(`{"name":"vm"}`)) {
+		t.Errorf("unexpected content length: %d", captured.ContentLength)
+	}
+}
@@ -4,12 +4,17 @@ package proxy
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
 )
 
@@ -18,12 +23,20 @@ import (
 const (
 	defaultCombinerName = "default"
 	sequentialKey       = "sequential"
+	dagKey              = "dag"
+	mergeBudgetKey      = "merge_budget"
+	replicateBodyKey    = "replicate_body"
+
+	// Endpoint Timeout 중에서 Merging 처리에 사용할 기본 비율
+	defaultMergeBudgetRatio = 0.85
 )
 
 var (
-	errNullResult     = errors.New("invalid response")
-	responseCombiners = initResponseCombiners()
-	reMergeKey        = regexp.MustCompile(`\{\{\.Resp(\d+)_([\d\w-_\.]+)\}\}`)
+	errNullResult       = errors.New("invalid response")
+	errDependencyFailed = errors.New("dependent backend failed")
+	errBodyTooLarge     = core.NewWrappedError(http.StatusRequestEntityTooLarge, "request body too large", nil)
+	responseCombiners   = initResponseCombiners()
+	reMergeKey          = regexp.MustCompile(`\{\{\.Resp(\d+)_([\d\w-_\.]+)\}\}`)
 )
 
 // ===== [ Types ] =====
@@ -31,15 +44,36 @@ var (
 type (
 	// incrementalMergeAccumulator - 점진적인 Merging 처리를 위한 데이터 구조
 	incrementalMergeAccumulator struct {
-		pending  int
-		data     *Response
-		combiner ResponseCombiner
-		errs     []error
+		pending        int
+		data           *Response
+		combiner       ResponseCombiner
+		backends       []*config.BackendConfig
+		errs           []BackendError
+		requiredFailed bool
+		headerConf     *responseHeaderConfig
+		headers        []map[string][]string
+		fanouts        []*fanoutConfig
 	}
 
-	// mergeError - Merging 과정에서 발생하는 오류들 관리 구조
+	// mergeError - Merging 과정에서 발생하는 Backend 별 오류들 관리 구조
 	mergeError struct {
-		errs []error
+		errs []BackendError
+	}
+
+	// BackendError - Merging 과정에서 발생한 Backend 단위의 오류 정보 구조
+	BackendError struct {
+		// Backend 설정 순서 (0 부터 시작)
+		Backend int `json:"backend"`
+		// Backend URL Pattern
+		URLPattern string `json:"url_pattern"`
+		// 필수 Backend 여부
+		Required bool `json:"required"`
+		// Fallback 데이터 사용 여부
+		Fallback bool `json:"fallback"`
+		// 오류 상태 코드
+		StatusCode int `json:"status_code"`
+		// 오류 메시지
+		Message string `json:"message"`
 	}
 
 	// ResponseCombiner - 여러 Response의 데이터를 Merging 처리해서 하나의 Response 데이터로 구성하는 함수 정의
@@ -54,11 +88,24 @@ type (
 
 // ===== [ Implementations ] =====
 
-// Merge - 지정한 Response에 대한 점진적인 Merging 처리
-func (ima *incrementalMergeAccumulator) Merge(res *Response, err error) {
+// Merge - 지정한 순서의 Backend Response에 대한 점진적인 Merging 처리
+func (ima *incrementalMergeAccumulator) Merge(bIdx int, res *Response, err error) {
 	ima.pending--
+	if err == nil && res == nil {
+		// 정상이지만 Response가 없는 경우
+		err = errNullResult
+	}
 	if err != nil {
-		ima.errs = append(ima.errs, err)
+		if ima.addError(bIdx, err) {
+			return
+		}
+
+		// 선택적 Backend의 Fallback 데이터가 지정된 경우는 Merging 대상으로 사용
+		if fallback := ima.fallback(bIdx); fallback != nil {
+			ima.errs[len(ima.errs)-1].Fallback = true
+			ima.combine(fallback)
+		}
+
 		if ima.data != nil {
 			ima.data.IsComplete = false
 		} else if res != nil {
@@ -68,12 +115,33 @@ func (ima *incrementalMergeAccumulator) Merge(res *Response, err error) {
 		}
 		return
 	}
-	if res == nil {
-		// 정상이지만 Response가 없는 경우
-		ima.errs = append(ima.errs, errNullResult)
+
+	// Combiner는 첫번째 Response의 Metadata만 유지하므로 Backend 별 Header 정보 보관
+	if bIdx < len(ima.headers) {
+		ima.headers[bIdx] = res.Metadata.Headers
+	}
+	// Fan-out 결과는 Combiner와 관계없이 원본 항목들을 대체
+	if bIdx < len(ima.fanouts) && ima.fanouts[bIdx] != nil && ima.replaceItems(ima.fanouts[bIdx], res) {
 		return
 	}
+	ima.combine(res)
+}
+
+// replaceItems - 지정한 Fan-out 설정의 경로에 해당하는 Merging 데이터의 항목 배열을 Fan-out 결과 항목으로 교체 (교체할 항목 배열이 없는 경우는 false)
+func (ima *incrementalMergeAccumulator) replaceItems(fConf *fanoutConfig, res *Response) bool {
+	if ima.data == nil {
+		return false
+	}
+	items, ok := lookupItems(res.Data, fConf.Path)
+	if !ok || !setItems(ima.data.Data, fConf.Path, items) {
+		return false
+	}
+	ima.data.IsComplete = ima.data.IsComplete && res.IsComplete
+	return true
+}
 
+// combine - 지정한 Response를 이전 데이터와 Merging 처리
+func (ima *incrementalMergeAccumulator) combine(res *Response) {
 	if ima.data == nil {
 		// 정상이면 Resposne 존재하는 경우
 		ima.data = res
@@ -84,8 +152,47 @@ func (ima *incrementalMergeAccumulator) Merge(res *Response, err error) {
 	ima.data = ima.combiner(2, []*Response{ima.data, res})
 }
 
+// addError - 지정한 순서의 Backend 오류를 등록하고 필수 Backend 여부 반환
+func (ima *incrementalMergeAccumulator) addError(bIdx int, err error) bool {
+	be := BackendError{
+		Backend:    bIdx,
+		StatusCode: errorStatusCode(err),
+		Message:    err.Error(),
+	}
+	if bIdx < len(ima.backends) {
+		be.URLPattern = ima.backends[bIdx].URLPattern
+		be.Required = ima.backends[bIdx].Required
+	}
+	ima.errs = append(ima.errs, be)
+
+	if be.Required {
+		ima.requiredFailed = true
+	}
+	return be.Required
+}
+
+// fallback - 지정한 순서의 Backend에 설정된 Fallback 데이터로 Response 구성 (미 지정시 nil)
+func (ima *incrementalMergeAccumulator) fallback(bIdx int) *Response {
+	if bIdx >= len(ima.backends) || len(ima.backends[bIdx].Fallback) == 0 {
+		return nil
+	}
+	bConf := ima.backends[bIdx]
+
+	// Combiner가 Response 데이터를 변경하므로 설정 데이터를 복제해서 사용
+	data := cloneData(bConf.Fallback).(map[string]interface{})
+	if bConf.Group != "" {
+		data = map[string]interface{}{bConf.Group: data}
+	}
+	return &Response{Data: data, IsComplete: false}
+}
+
 // Result - 처리된 Merging 결과 반환
 func (ima *incrementalMergeAccumulator) Result() (*Response, error) {
+	// 필수 Backend가 실패한 경우는 Endpoint 전체 실패로 처리
+	if ima.requiredFailed {
+		return nil, newMergeError(ima.errs)
+	}
+
 	if ima.data == nil {
 		return &Response{Data: make(map[string]interface{}), IsComplete: false}, newMergeError(ima.errs)
 	}
@@ -93,31 +200,82 @@ func (ima *incrementalMergeAccumulator) Result() (*Response, error) {
 	if ima.pending != 0 || len(ima.errs) != 0 {
 		ima.data.IsComplete = false
 	}
+	ima.data.Metadata.Headers = mergeResponseHeaders(ima.headerConf, ima.headers)
 	return ima.data, newMergeError(ima.errs)
 }
 
 // Error - Merging 작업 중에 발생한 오류 메시지 반환
 func (me mergeError) Error() string {
 	msg := make([]string, len(me.errs))
-	for i, err := range me.errs {
-		msg[i] = err.Error()
+	for i, be := range me.errs {
+		msg[i] = fmt.Sprintf("backend[%d] %s: %s", be.Backend, be.URLPattern, be.Message)
 	}
-	return strings.Join(msg, "\n")
+	return strings.Join(msg, "; ")
+}
+
+// StatusCode - 실패한 필수 Backend의 상태 코드 반환 (없는 경우는 첫번째 오류의 상태 코드)
+func (me mergeError) StatusCode() int {
+	for _, be := range me.errs {
+		if be.Required {
+			return be.StatusCode
+		}
+	}
+	return me.errs[0].StatusCode
+}
+
+// BackendErrors - Backend 별 오류 정보 반환
+func (me mergeError) BackendErrors() []BackendError {
+	return me.errs
 }
 
 // ===== [ Private Functions ] =====
 
 // newMergeError - Merging 처리 중에 발생한 오류들을 하나의 오류로 반환
-func newMergeError(errs []error) error {
+func newMergeError(errs []BackendError) error {
 	if len(errs) == 0 {
 		return nil
 	}
 	return mergeError{errs}
 }
 
+// errorStatusCode - 지정한 오류에 해당하는 HTTP 상태 코드 반환
+func errorStatusCode(err error) int {
+	switch e := err.(type) {
+	case responseError:
+		return e.StatusCode()
+	case core.WrappedError:
+		return e.Code()
+	}
+	if err == context.DeadlineExceeded {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
+
+// cloneData - 지정한 Response 데이터 (map, array)를 Deep Copy 처리
+func cloneData(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = cloneData(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(t))
+		for i, e := range t {
+			a[i] = cloneData(e)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
 // requestPart - 지정한 요청을 호출하고 오류와 Response 정보를 반환
-func requestPart(ctx context.Context, next Proxy, req *Request, out chan<- *partResult) {
-	localCtx, cancel := context.WithCancel(ctx)
+func requestPart(ctx context.Context, next Proxy, req *Request, timeout time.Duration, out chan<- *partResult) {
+	// Backend 별 Timeout 적용 (Merging Timeout 보다 긴 경우는 Merging Timeout 적용)
+	localCtx, cancel := newTimeoutContext(ctx, timeout)
 
 	// Backend Request 호출
 	res, err := next(localCtx, req)
@@ -159,12 +317,16 @@ func requestPart(ctx context.Context, next Proxy, req *Request, out chan<- *part
 	cancel()
 }
 
-// newIncrementalMergeAccumultor - 지정한 Backend count 와 ResponseCombiner를 설정한 점진적인 Merge 처리기 생성
-func newIncrementalMergeAccumultor(backendCount int, rc ResponseCombiner) *incrementalMergeAccumulator {
+// newIncrementalMergeAccumultor - 지정한 Backend 설정들과 ResponseCombiner, Response Header 전달 설정, Fan-out 설정들을 설정한 점진적인 Merge 처리기 생성
+func newIncrementalMergeAccumultor(backends []*config.BackendConfig, rc ResponseCombiner, hc *responseHeaderConfig, fanouts []*fanoutConfig) *incrementalMergeAccumulator {
 	return &incrementalMergeAccumulator{
-		pending:  backendCount,
-		combiner: rc,
-		errs:     []error{},
+		pending:    len(backends),
+		combiner:   rc,
+		backends:   backends,
+		errs:       []BackendError{},
+		headerConf: hc,
+		headers:    make([]map[string][]string, len(backends)),
+		fanouts:    fanouts,
 	}
 }
 
@@ -199,12 +361,16 @@ func combineData(backendCount int, reses []*Response) *Response {
 
 // initResponseCombiners - Response 데이터를 Merging 하는 ResponseCombiner 초기화
 func initResponseCombiners() *combinerRegister {
-	return newCombinerRegister(map[string]ResponseCombiner{defaultCombinerName: combineData}, combineData)
+	return newCombinerRegister(defaultResponseCombiners(), combineData)
 }
 
-// getResponseCombiner - 기본적으로 사용되는 ResponseCombiner 반환
-func getResponseCombiner() ResponseCombiner {
-	combiner, _ := responseCombiners.GetResponseCombiner(defaultCombinerName)
+// getResponseCombiner - 지정된 Endpoint 설정의 "combiner" 에 해당하는 ResponseCombiner 반환 (없는 경우는 기본 Combiner 사용)
+func getResponseCombiner(eConf *config.EndpointConfig) ResponseCombiner {
+	name := getCombinerName(eConf)
+	combiner, ok := responseCombiners.GetResponseCombiner(name)
+	if !ok {
+		logger.Warnf("[API G/W] Proxy > Unknown response combiner '%s' on %s, using default combiner", name, eConf.Endpoint)
+	}
 	return combiner
 }
 
@@ -221,22 +387,81 @@ func shouldRunSequentialMerger(eConf *config.EndpointConfig) bool {
 	return false
 }
 
-// parallelMerge - 지정한 시간내에 Timeout 발생하는 Context 기반으로 Request를 처리하고 도착하는 Response를 병렬로 처리
-func parallelMerge(timeout time.Duration, rc ResponseCombiner, next ...Proxy) Proxy {
+// getMergeBudgetRatio - 지정된 Endpoint 설정에서 Merging 처리에 사용할 Timeout 비율 추출 (0 < ratio <= 1, 미 지정 또는 범위 밖인 경우는 0.85)
+func getMergeBudgetRatio(eConf *config.EndpointConfig) float64 {
+	if v, ok := eConf.Middleware[MWNamespace]; ok {
+		if e, ok := v.(config.MWConfig); ok {
+			var ratio float64
+			switch t := e[mergeBudgetKey].(type) {
+			case float64:
+				ratio = t
+			case int:
+				ratio = float64(t)
+			}
+			if ratio > 0 && ratio <= 1 {
+				return ratio
+			}
+			if _, ok := e[mergeBudgetKey]; ok {
+				logger.Warnf("[API G/W] Proxy > Invalid merge budget '%v' on %s, using default ratio %v", e[mergeBudgetKey], eConf.Endpoint, defaultMergeBudgetRatio)
+			}
+		}
+	}
+	return defaultMergeBudgetRatio
+}
+
+// replicateBody - 지정한 최대 크기 내에서 Request Body를 메모리에 보관해서 각 Backend 호출에 재 전송할 수 있도록 처리하는 Proxy 구성
+func replicateBody(maxSize int64, next Proxy) Proxy {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if req.Body == nil {
+			return next(ctx, req)
+		}
+
+		data, err := ioutil.ReadAll(io.LimitReader(req.Body, maxSize+1))
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) > maxSize {
+			return nil, errBodyTooLarge
+		}
+
+		r := req.Clone()
+		r.Body = newBufferedBody(data)
+		return next(ctx, &r)
+	}
+}
+
+// shouldRunDAGMerger - 지정된 설정 정보를 기준으로 Merging이 Backend 의존관계 (DAG) 기준으로 처리가 되어야할지 검증
+func shouldRunDAGMerger(eConf *config.EndpointConfig) bool {
+	if v, ok := eConf.Middleware[MWNamespace]; ok {
+		if e, ok := v.(config.MWConfig); ok {
+			if v, ok := e[dagKey]; ok {
+				c, ok := v.(bool)
+				return ok && c
+			}
+		}
+	}
+	return false
+}
+
+// parallelMerge - 지정한 시간내에 Timeout 발생하는 Context 기반으로 Request를 병렬로 처리하고 Backend 설정 순서대로 Response를 Merging 처리
+func parallelMerge(backends []*config.BackendConfig, timeout time.Duration, rc ResponseCombiner, hc *responseHeaderConfig, next ...Proxy) Proxy {
 	return func(ctx context.Context, req *Request) (*Response, error) {
 		localCtx, cancel := context.WithTimeout(ctx, timeout)
 
-		out := make(chan *partResult, len(next))
+		// Combiner의 충돌 처리 (first/last wins)가 도착 순서와 무관하도록 Backend 별 채널 구성
+		outs := make([]chan *partResult, len(next))
 
 		// 병렬로 Backend 호출
-		for _, n := range next {
-			go requestPart(localCtx, n, req, out)
+		for i, n := range next {
+			outs[i] = make(chan *partResult, 1)
+			go requestPart(localCtx, n, req, backends[i].Timeout, outs[i])
 		}
 
-		acc := newIncrementalMergeAccumultor(len(next), rc)
-		for i := 0; i < len(next); i++ {
-			resultData := <-out
-			acc.Merge(resultData.Response, resultData.Error)
+		acc := newIncrementalMergeAccumultor(backends, rc, hc, nil)
+		for i := range outs {
+			resultData := <-outs[i]
+			acc.Merge(i, resultData.Response, resultData.Error)
 		}
 
 		result, err := acc.Result()
@@ -246,7 +471,9 @@ func parallelMerge(timeout time.Duration, rc ResponseCombiner, next ...Proxy) Pr
 }
 
 // sequentialMerge - 지정한 시간내에 Timeout 발생하는 Context 기반으로 Request를 순차적으로 처리하고 이전 Response의 결과를 파라미터로 처리해서 다음 Request를 처리하는 방식으로 순차 처리
-func sequentialMerge(patterns []string, timeout time.Duration, rc ResponseCombiner, next ...Proxy) Proxy {
+func sequentialMerge(backends []*config.BackendConfig, timeout time.Duration, rc ResponseCombiner, hc *responseHeaderConfig, next ...Proxy) Proxy {
+	fanouts := parseFanoutConfigs(backends)
+
 	return func(ctx context.Context, req *Request) (*Response, error) {
 		localCtx, cancel := context.WithTimeout(ctx, timeout)
 
@@ -254,74 +481,39 @@ func sequentialMerge(patterns []string, timeout time.Duration, rc ResponseCombin
 
 		out := make(chan *partResult, 1)
 
-		acc := newIncrementalMergeAccumultor(len(next), rc)
+		acc := newIncrementalMergeAccumultor(backends, rc, hc, fanouts)
 	TxLoop:
 		for i, n := range next {
-			// 두번째 부터 전 호출의 결과에서 파라미터 검증
-			if i > 0 {
-				for _, match := range reMergeKey.FindAllStringSubmatch(patterns[i], -1) {
-					if len(match) > 1 {
-						rNum, err := strconv.Atoi(match[1])
-						if err != nil || rNum >= i || parts[rNum] == nil {
-							continue
-						}
-						key := "Resp" + match[1] + "_" + match[2]
-
-						var v interface{}
-						var ok bool
-
-						data := parts[rNum].Data
-						keys := strings.Split(match[2], ".")
-						if len(keys) > 1 {
-							for _, k := range keys[:len(keys)-1] {
-								v, ok = data[k]
-								if !ok {
-									break
-								}
-								switch clean := v.(type) {
-								case map[string]interface{}:
-									data = clean
-								default:
-									break
-
-								}
-							}
-						}
-
-						v, ok = data[keys[len(keys)-1]]
-						if !ok {
-							continue
-						}
-						switch clean := v.(type) {
-						case string:
-							req.Params[key] = clean
-						case int:
-							req.Params[key] = strconv.Itoa(clean)
-						case float64:
-							req.Params[key] = strconv.FormatFloat(clean, 'E', -1, 32)
-						case bool:
-							req.Params[key] = strconv.FormatBool(clean)
-						default:
-							req.Params[key] = fmt.Sprintf("%v", v)
-						}
-					}
+			if fanouts[i] != nil {
+				// 이전 응답의 항목 별 호출
+				requestFanout(localCtx, n, req, i, backends[i], fanouts[i], parts, out)
+			} else {
+				// 두번째 부터 전 호출의 결과에서 파라미터 검증
+				if i > 0 {
+					setResponseParams(backends[i], i, parts, req.Params)
 				}
-			}
 
-			// 순차적 호출
-			requestPart(localCtx, n, req, out)
+				// 순차적 호출
+				requestPart(localCtx, n, req, backends[i].Timeout, out)
+			}
 			resultData := <-out
 			if resultData.Error != nil {
-				if i == 0 {
+				acc.Merge(i, resultData.Response, resultData.Error)
+
+				// 첫번째 Backend는 이후 호출의 기준이므로 Fallback이 없는 경우는 오류로 종료
+				if i == 0 && len(backends[0].Fallback) == 0 {
 					cancel()
-					return resultData.Response, resultData.Error
+					_, err := acc.Result()
+					if backends[0].Required {
+						return nil, err
+					}
+					return resultData.Response, err
 				}
-
-				acc.Merge(resultData.Response, resultData.Error)
 				break TxLoop
 			} else {
-				acc.Merge(resultData.Response, resultData.Error)
-				if resultData.Response.IsComplete {
+				acc.Merge(i, resultData.Response, resultData.Error)
+				// 불완전한 Response는 이후 호출의 파라미터로 신뢰할 수 없으므로 종료
+				if !resultData.Response.IsComplete {
 					break TxLoop
 				}
 				parts[i] = resultData.Response
@@ -334,8 +526,180 @@ func sequentialMerge(patterns []string, timeout time.Duration, rc ResponseCombin
 	}
 }
 
+// dagMerge - 지정한 시간내에 Timeout 발생하는 Context 기반으로 Backend 의존관계 (depends_on 및 {{.RespN_xxx}} 파라미터)에 따라
+// 독립적인 Backend는 병렬로, 의존하는 Backend는 선행 Backend 완료 후 호출하고 Backend 설정 순서대로 Response를 Merging 처리
+func dagMerge(backends []*config.BackendConfig, timeout time.Duration, rc ResponseCombiner, hc *responseHeaderConfig, next ...Proxy) Proxy {
+	fanouts := parseFanoutConfigs(backends)
+	deps := make([][]int, len(backends))
+	for i, b := range backends {
+		deps[i] = backendDependencies(i, b, fanouts[i])
+	}
+
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		localCtx, cancel := context.WithTimeout(ctx, timeout)
+
+		parts := make([]*Response, len(next))
+		dones := make([]chan struct{}, len(next))
+		outs := make([]chan *partResult, len(next))
+		for i := range next {
+			dones[i] = make(chan struct{})
+			outs[i] = make(chan *partResult, 1)
+		}
+
+		for i, n := range next {
+			go func(i int, n Proxy) {
+				defer close(dones[i])
+
+				// 선행 Backend 완료 대기
+				for _, dep := range deps[i] {
+					select {
+					case <-dones[dep]:
+					case <-localCtx.Done():
+						outs[i] <- &partResult{Error: localCtx.Err()}
+						return
+					}
+					if parts[dep] == nil {
+						outs[i] <- &partResult{Error: errDependencyFailed}
+						return
+					}
+				}
+
+				out := make(chan *partResult, 1)
+				if fanouts[i] != nil {
+					// 선행 응답의 항목 별 호출
+					requestFanout(localCtx, n, req, i, backends[i], fanouts[i], parts, out)
+				} else {
+					// 병렬 호출 간에 파라미터가 공유되지 않도록 Request 복제
+					r := CloneRequest(req)
+					setResponseParams(backends[i], i, parts, r.Params)
+					requestPart(localCtx, n, r, backends[i].Timeout, out)
+				}
+				pr := <-out
+				if pr.Error == nil {
+					parts[i] = pr.Response
+				}
+				outs[i] <- pr
+			}(i, n)
+		}
+
+		// Combiner가 Response 데이터를 변경하므로 모든 Backend 처리가 완료된 후에 Merging 처리
+		results := make([]*partResult, len(next))
+		for i := range outs {
+			results[i] = <-outs[i]
+		}
+
+		acc := newIncrementalMergeAccumultor(backends, rc, hc, fanouts)
+		for i, resultData := range results {
+			acc.Merge(i, resultData.Response, resultData.Error)
+		}
+
+		result, err := acc.Result()
+		cancel()
+		return result, err
+	}
+}
+
+// backendDependencies - 지정한 순서의 Backend가 의존하는 이전 Backend 순서 목록 (depends_on, {{.RespN_xxx}} 파라미터 및 Fan-out Source) 반환
+func backendDependencies(bIdx int, bConf *config.BackendConfig, fConf *fanoutConfig) []int {
+	set := map[int]bool{}
+	if fConf != nil {
+		set[fConf.Source] = true
+	}
+	for _, dep := range bConf.DependsOn {
+		if dep >= 0 && dep < bIdx {
+			set[dep] = true
+		}
+	}
+	for _, match := range responseParamMatches(bConf) {
+		if rNum, err := strconv.Atoi(match[1]); err == nil && rNum < bIdx {
+			set[rNum] = true
+		}
+	}
+
+	deps := make([]int, 0, len(set))
+	for dep := range set {
+		deps = append(deps, dep)
+	}
+	sort.Ints(deps)
+	return deps
+}
+
+// responseParamMatches - 지정한 Backend 설정의 URL Pattern, Header, Query String, Body 템플릿 (조작 규칙 및 Request Body 변환 포함) 에 존재하는 {{.RespN_xxx}} 파라미터 목록 반환
+func responseParamMatches(bConf *config.BackendConfig) [][]string {
+	matches := reMergeKey.FindAllStringSubmatch(bConf.URLPattern, -1)
+	for _, v := range bConf.RequestHeaders {
+		matches = append(matches, reMergeKey.FindAllStringSubmatch(v, -1)...)
+	}
+	for _, v := range bConf.RequestQuery {
+		matches = append(matches, reMergeKey.FindAllStringSubmatch(v, -1)...)
+	}
+	for _, v := range ruleTemplateValues(bConf) {
+		matches = append(matches, reMergeKey.FindAllStringSubmatch(v, -1)...)
+	}
+	for _, v := range requestTemplateValues(bConf) {
+		matches = append(matches, reMergeKey.FindAllStringSubmatch(v, -1)...)
+	}
+	return append(matches, reMergeKey.FindAllStringSubmatch(bConf.RequestBody, -1)...)
+}
+
+// setResponseParams - 지정한 Backend 설정의 {{.RespN_xxx}} 파라미터를 이전 Backend Response 데이터에서 추출해서 파라미터로 설정
+func setResponseParams(bConf *config.BackendConfig, bIdx int, parts []*Response, params map[string]string) {
+	for _, match := range responseParamMatches(bConf) {
+		if len(match) > 1 {
+			rNum, err := strconv.Atoi(match[1])
+			if err != nil || rNum >= bIdx || parts[rNum] == nil {
+				continue
+			}
+			key := "Resp" + match[1] + "_" + match[2]
+
+			var v interface{}
+			var ok bool
+
+			data := parts[rNum].Data
+			keys := strings.Split(match[2], ".")
+			if len(keys) > 1 {
+				for _, k := range keys[:len(keys)-1] {
+					v, ok = data[k]
+					if !ok {
+						break
+					}
+					switch clean := v.(type) {
+					case map[string]interface{}:
+						data = clean
+					default:
+						break
+
+					}
+				}
+			}
+
+			v, ok = data[keys[len(keys)-1]]
+			if !ok {
+				continue
+			}
+			switch clean := v.(type) {
+			case string:
+				params[key] = clean
+			case int:
+				params[key] = strconv.Itoa(clean)
+			case float64:
+				params[key] = strconv.FormatFloat(clean, 'f', -1, 64)
+			case bool:
+				params[key] = strconv.FormatBool(clean)
+			default:
+				params[key] = fmt.Sprintf("%v", v)
+			}
+		}
+	}
+}
+
 // ===== [ Public Functions ] =====
 
+// CloneData - 지정한 Response 데이터 (map, array)를 Deep Copy 처리
+func CloneData(v interface{}) interface{} {
+	return cloneData(v)
+}
+
 // NewMergeDataChain - 전달된 Endpoint 설정을 기준으로 Backend 갯수에 따라서 Response를 Merging 하는 Proxy Call chain 생성
 func NewMergeDataChain(eConf *config.EndpointConfig) CallChain {
 	totalBackends := len(eConf.Backend)
@@ -346,20 +710,40 @@ func NewMergeDataChain(eConf *config.EndpointConfig) CallChain {
 		return EmptyChain
 	}
 
-	serviceTimeout := time.Duration(85*eConf.Timeout.Nanoseconds()/100) * time.Nanosecond
-	combiner := getResponseCombiner()
+	serviceTimeout := time.Duration(getMergeBudgetRatio(eConf)*float64(eConf.Timeout.Nanoseconds())) * time.Nanosecond
+	combiner := getResponseCombiner(eConf)
+	headerConf := parseResponseHeaderConfig(eConf)
 
 	return func(next ...Proxy) Proxy {
 		if len(next) != totalBackends {
 			panic(ErrNotEnoughProxies)
 		}
-		if !shouldRunSequentialMerger(eConf) {
-			return parallelMerge(serviceTimeout, combiner, next...)
+
+		var p Proxy
+		if shouldRunDAGMerger(eConf) {
+			p = dagMerge(eConf.Backend, serviceTimeout, combiner, headerConf, next...)
+		} else if !shouldRunSequentialMerger(eConf) {
+			p = parallelMerge(eConf.Backend, serviceTimeout, combiner, headerConf, next...)
+		} else {
+			p = sequentialMerge(eConf.Backend, serviceTimeout, combiner, headerConf, next...)
 		}
-		patterns := make([]string, totalBackends)
-		for i, b := range eConf.Backend {
-			patterns[i] = b.URLPattern
+
+		if IsBodyReplicationEnabled(eConf) {
+			p = replicateBody(eConf.MaxBodySize(), p)
 		}
-		return sequentialMerge(patterns, serviceTimeout, combiner, next...)
+		return p
 	}
 }
+
+// IsBodyReplicationEnabled - 지정된 Endpoint 설정이 여러 Backend에 Request Body를 복제해서 전달하도록 설정되었는지 검증
+func IsBodyReplicationEnabled(eConf *config.EndpointConfig) bool {
+	if v, ok := eConf.Middleware[MWNamespace]; ok {
+		if e, ok := v.(config.MWConfig); ok {
+			if v, ok := e[replicateBodyKey]; ok {
+				c, ok := v.(bool)
+				return ok && c
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,522 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+)
+
+func TestSetResponseParams(t *testing.T) {
+	bConf := &config.BackendConfig{
+		URLPattern:  "/items/{{.Resp0_id}}",
+		RequestBody: `{"id": {{.Resp0_id}}, "ratio": {{.Resp0_ratio}}, "name": "{{.Resp0_missing}}"}`,
+	}
+	parts := []*Response{{Data: map[string]interface{}{"id": float64(123456789), "ratio": 0.25}}}
+	params := map[string]string{}
+
+	setResponseParams(bConf, 1, parts, params)
+	if params["Resp0_id"] != "123456789" {
+		t.Errorf("unexpected id param: %s", params["Resp0_id"])
+	}
+	if params["Resp0_ratio"] != "0.25" {
+		t.Errorf("unexpected ratio param: %s", params["Resp0_ratio"])
+	}
+
+	body := replaceParams(bConf.RequestBody, params, escapeJSONString)
+	if expected := `{"id": 123456789, "ratio": 0.25, "name": ""}`; body != expected {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
+
+func newProxyMiddleware(values config.MWConfig) config.MWConfig {
+	return config.MWConfig{MWNamespace: values}
+}
+
+func TestGetMergeBudgetRatio(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		mw       config.MWConfig
+		expected float64
+	}{
+		{name: "not configured", expected: defaultMergeBudgetRatio},
+		{name: "ratio", mw: newProxyMiddleware(config.MWConfig{mergeBudgetKey: 0.5}), expected: 0.5},
+		{name: "whole timeout", mw: newProxyMiddleware(config.MWConfig{mergeBudgetKey: 1}), expected: 1},
+		{name: "zero", mw: newProxyMiddleware(config.MWConfig{mergeBudgetKey: 0}), expected: defaultMergeBudgetRatio},
+		{name: "over one", mw: newProxyMiddleware(config.MWConfig{mergeBudgetKey: 1.5}), expected: defaultMergeBudgetRatio},
+		{name: "invalid type", mw: newProxyMiddleware(config.MWConfig{mergeBudgetKey: "half"}), expected: defaultMergeBudgetRatio},
+	} {
+		eConf := &config.EndpointConfig{Endpoint: "/budget", Middleware: tc.mw}
+		if got := getMergeBudgetRatio(eConf); got != tc.expected {
+			t.Errorf("%s: unexpected ratio: %v", tc.name, got)
+		}
+	}
+}
+
+func TestNewTimeoutContext(t *testing.T) {
+	ctx, cancel := newTimeoutContext(context.Background(), 0)
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("context without timeout should not have deadline")
+	}
+	cancel()
+	if ctx.Err() != context.Canceled {
+		t.Errorf("context should be canceled: %v", ctx.Err())
+	}
+
+	parent, parentCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer parentCancel()
+	parentDeadline, _ := parent.Deadline()
+
+	// 상위 Context 보다 긴 Timeout은 상위 Context의 기한 적용
+	ctx, cancel = newTimeoutContext(parent, time.Hour)
+	if deadline, _ := ctx.Deadline(); !deadline.Equal(parentDeadline) {
+		t.Errorf("longer timeout should use parent deadline: %v", deadline)
+	}
+	cancel()
+
+	ctx, cancel = newTimeoutContext(parent, 10*time.Millisecond)
+	defer cancel()
+	if deadline, _ := ctx.Deadline(); !deadline.Before(parentDeadline) {
+		t.Errorf("shorter timeout should be applied: %v", deadline)
+	}
+	<-ctx.Done()
+	if ctx.Err() != context.DeadlineExceeded || parent.Err() != nil {
+		t.Errorf("only backend context should be expired: %v, %v", ctx.Err(), parent.Err())
+	}
+}
+
+func TestNewMergeDataChain_backendTimeout(t *testing.T) {
+	eConf := &config.EndpointConfig{
+		Endpoint:   "/timeout",
+		Timeout:    400 * time.Millisecond,
+		Middleware: newProxyMiddleware(config.MWConfig{mergeBudgetKey: 0.5}),
+		Backend: []*config.BackendConfig{
+			{URLPattern: "/fast"},
+			{URLPattern: "/slow", Timeout: 20 * time.Millisecond},
+			{URLPattern: "/slower"},
+		},
+	}
+	fast := func(_ context.Context, _ *Request) (*Response, error) {
+		return &Response{Data: map[string]interface{}{"fast": true}, IsComplete: true}, nil
+	}
+	begin := time.Now()
+	elapsed := make([]time.Duration, 2)
+	slow := func(i int) Proxy {
+		return func(ctx context.Context, _ *Request) (*Response, error) {
+			<-ctx.Done()
+			elapsed[i] = time.Since(begin)
+			return nil, ctx.Err()
+		}
+	}
+
+	res, err := NewMergeDataChain(eConf)(fast, slow(0), slow(1))(context.Background(), &Request{Params: map[string]string{}})
+
+	me, ok := err.(mergeError)
+	if !ok || len(me.errs) != 2 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, be := range me.errs {
+		if be.StatusCode != http.StatusGatewayTimeout {
+			t.Errorf("backend[%d] should be timed out: %+v", be.Backend, be)
+		}
+	}
+	if res == nil || res.Data["fast"] != true || res.IsComplete {
+		t.Errorf("unexpected response: %+v", res)
+	}
+	// Backend Timeout이 지정된 경우는 Backend Timeout 적용
+	if elapsed[0] > 150*time.Millisecond {
+		t.Errorf("backend timeout should be applied, but %s", elapsed[0])
+	}
+	// Backend Timeout이 없는 경우는 Merging 제한 시간 (Endpoint Timeout * merge_budget) 적용
+	if elapsed[1] < 190*time.Millisecond || elapsed[1] > 350*time.Millisecond {
+		t.Errorf("merge should end at the merge budget, but %s", elapsed[1])
+	}
+}
+
+// staticProxy - 지정한 데이터 또는 오류를 반환하는 Backend Proxy
+func staticProxy(data map[string]interface{}, err error) Proxy {
+	return func(_ context.Context, _ *Request) (*Response, error) {
+		if err != nil {
+			return nil, err
+		}
+		return &Response{Data: data, IsComplete: true, Metadata: Metadata{StatusCode: http.StatusOK}}, nil
+	}
+}
+
+func TestNewMergeDataChain_backendFailures(t *testing.T) {
+	unavailable := core.NewWrappedError(http.StatusServiceUnavailable, "unavailable", nil)
+	hotel := map[string]interface{}{"hotel": "h1"}
+	fallback := map[string]interface{}{"destination": "unknown"}
+
+	for _, tc := range []struct {
+		name       string
+		sequential bool
+		backends   []*config.BackendConfig
+		errs       []error
+		data       map[string]interface{}
+		nilResult  bool
+		status     int
+		expected   []BackendError
+	}{
+		{
+			name:     "all succeeded",
+			backends: []*config.BackendConfig{{URLPattern: "/hotels"}, {URLPattern: "/destinations"}},
+			errs:     []error{nil, nil},
+			data:     map[string]interface{}{"hotel": "h1", "destination": "d1"},
+		},
+		{
+			name:     "optional failed",
+			backends: []*config.BackendConfig{{URLPattern: "/hotels"}, {URLPattern: "/destinations"}},
+			errs:     []error{nil, unavailable},
+			data:     hotel,
+			status:   http.StatusServiceUnavailable,
+			expected: []BackendError{{Backend: 1, URLPattern: "/destinations", StatusCode: http.StatusServiceUnavailable, Message: "503, unavailable"}},
+		},
+		{
+			name:     "optional failed with fallback",
+			backends: []*config.BackendConfig{{URLPattern: "/hotels"}, {URLPattern: "/destinations", Fallback: fallback}},
+			errs:     []error{nil, unavailable},
+			data:     map[string]interface{}{"hotel": "h1", "destination": "unknown"},
+			status:   http.StatusServiceUnavailable,
+			expected: []BackendError{{Backend: 1, URLPattern: "/destinations", Fallback: true, StatusCode: http.StatusServiceUnavailable, Message: "503, unavailable"}},
+		},
+		{
+			name:     "grouped fallback",
+			backends: []*config.BackendConfig{{URLPattern: "/hotels"}, {URLPattern: "/destinations", Group: "dest", Fallback: fallback}},
+			errs:     []error{nil, errors.New("connection refused")},
+			data:     map[string]interface{}{"hotel": "h1", "dest": fallback},
+			status:   http.StatusBadGateway,
+			expected: []BackendError{{Backend: 1, URLPattern: "/destinations", Fallback: true, StatusCode: http.StatusBadGateway, Message: "connection refused"}},
+		},
+		{
+			name:      "required failed",
+			backends:  []*config.BackendConfig{{URLPattern: "/hotels", Required: true}, {URLPattern: "/destinations", Fallback: fallback}},
+			errs:      []error{unavailable, nil},
+			nilResult: true,
+			status:    http.StatusServiceUnavailable,
+			expected:  []BackendError{{Backend: 0, URLPattern: "/hotels", Required: true, StatusCode: http.StatusServiceUnavailable, Message: "503, unavailable"}},
+		},
+		{
+			// 필수 Backend의 상태 코드가 먼저 실패한 선택적 Backend 보다 우선
+			name:      "required status first",
+			backends:  []*config.BackendConfig{{URLPattern: "/hotels"}, {URLPattern: "/destinations", Required: true}},
+			errs:      []error{errors.New("connection refused"), unavailable},
+			nilResult: true,
+			status:    http.StatusServiceUnavailable,
+			expected: []BackendError{
+				{Backend: 0, URLPattern: "/hotels", StatusCode: http.StatusBadGateway, Message: "connection refused"},
+				{Backend: 1, URLPattern: "/destinations", Required: true, StatusCode: http.StatusServiceUnavailable, Message: "503, unavailable"},
+			},
+		},
+		{
+			// 순차 처리는 실패한 Backend 이후의 호출을 중단하고 Fallback 까지만 Merging
+			name:       "sequential failed with fallback",
+			sequential: true,
+			backends:   []*config.BackendConfig{{URLPattern: "/destinations", Fallback: fallback}, {URLPattern: "/hotels"}},
+			errs:       []error{unavailable, nil},
+			data:       fallback,
+			status:     http.StatusServiceUnavailable,
+			expected:   []BackendError{{Backend: 0, URLPattern: "/destinations", Fallback: true, StatusCode: http.StatusServiceUnavailable, Message: "503, unavailable"}},
+		},
+	} {
+		eConf := &config.EndpointConfig{Endpoint: "/failures", Timeout: time.Second, Backend: tc.backends}
+		if tc.sequential {
+			eConf.Middleware = newProxyMiddleware(config.MWConfig{sequentialKey: true})
+		}
+		next := make([]Proxy, len(tc.backends))
+		for i, b := range tc.backends {
+			data := map[string]interface{}{"hotel": "h1"}
+			if b.URLPattern == "/destinations" {
+				data = map[string]interface{}{"destination": "d1"}
+			}
+			next[i] = staticProxy(data, tc.errs[i])
+		}
+
+		res, err := NewMergeDataChain(eConf)(next...)(context.Background(), &Request{Params: map[string]string{}})
+		if tc.nilResult != (res == nil) {
+			t.Fatalf("%s: unexpected response: %+v", tc.name, res)
+		}
+		if res != nil {
+			if !reflect.DeepEqual(res.Data, tc.data) {
+				t.Errorf("%s: unexpected data: %v", tc.name, res.Data)
+			}
+			if res.IsComplete != (len(tc.expected) == 0) {
+				t.Errorf("%s: unexpected complete: %v", tc.name, res.IsComplete)
+			}
+		}
+		if len(tc.expected) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tc.name, err.Error())
+			}
+			continue
+		}
+
+		me, ok := err.(mergeError)
+		if !ok {
+			t.Fatalf("%s: unexpected error type: %T", tc.name, err)
+		}
+		if me.StatusCode() != tc.status {
+			t.Errorf("%s: unexpected status code: %d", tc.name, me.StatusCode())
+		}
+		if !reflect.DeepEqual(me.BackendErrors(), tc.expected) {
+			t.Errorf("%s: unexpected backend errors: %+v", tc.name, me.BackendErrors())
+		}
+	}
+}
+
+func TestMergeError_format(t *testing.T) {
+	me := mergeError{[]BackendError{
+		{Backend: 0, URLPattern: "/hotels", Required: true, StatusCode: http.StatusServiceUnavailable, Message: "unavailable"},
+		{Backend: 1, URLPattern: "/destinations", Fallback: true, StatusCode: http.StatusBadGateway, Message: "connection refused"},
+	}}
+
+	if expected := "backend[0] /hotels: unavailable; backend[1] /destinations: connection refused"; me.Error() != expected {
+		t.Errorf("unexpected message: %s", me.Error())
+	}
+
+	// 클라이언트에 전달되는 오류 Header 형식
+	expected := `[{"backend":0,"url_pattern":"/hotels","required":true,"fallback":false,"status_code":503,"message":"unavailable"},` +
+		`{"backend":1,"url_pattern":"/destinations","required":false,"fallback":true,"status_code":502,"message":"connection refused"}]`
+	if got := core.ToJSON(me.BackendErrors()); got != expected {
+		t.Errorf("unexpected errors header: %s", got)
+	}
+}
+
+// newDAGEndpoint - 0, 1 은 독립, 2 는 0 의 응답 파라미터, 3 은 1, 2 에 의존하는 DAG Merging Endpoint 설정
+func newDAGEndpoint() *config.EndpointConfig {
+	return &config.EndpointConfig{
+		Endpoint:   "/dag",
+		Timeout:    time.Second,
+		Middleware: newProxyMiddleware(config.MWConfig{dagKey: true}),
+		Backend: []*config.BackendConfig{
+			{URLPattern: "/users"},
+			{URLPattern: "/orders"},
+			{URLPattern: "/profiles/{{.Resp0_id}}"},
+			{URLPattern: "/summary", DependsOn: []int{1, 2}},
+		},
+	}
+}
+
+func TestDAGMerge_ordering(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(e string) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}
+
+	// 독립적인 Backend는 서로 시작을 확인해야 완료되므로 순차 처리되면 Timeout 발생
+	var started sync.WaitGroup
+	started.Add(2)
+	ready := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(ready)
+	}()
+	root := func(name string, data map[string]interface{}) Proxy {
+		return func(ctx context.Context, _ *Request) (*Response, error) {
+			started.Done()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(500 * time.Millisecond):
+				return nil, errors.New(name + " not running concurrently")
+			case <-ready:
+			}
+			record(name)
+			return &Response{Data: data, IsComplete: true}, nil
+		}
+	}
+	var profileID string
+	profile := func(_ context.Context, req *Request) (*Response, error) {
+		profileID = req.Params["Resp0_id"]
+		record("profile")
+		return &Response{Data: map[string]interface{}{"profile": "p-" + profileID}, IsComplete: true}, nil
+	}
+	summary := func(_ context.Context, _ *Request) (*Response, error) {
+		record("summary")
+		return &Response{Data: map[string]interface{}{"summary": true}, IsComplete: true}, nil
+	}
+
+	p := NewMergeDataChain(newDAGEndpoint())(
+		root("users", map[string]interface{}{"id": "u1"}),
+		root("orders", map[string]interface{}{"orders": 3.0}),
+		profile, summary)
+	res, err := p(context.Background(), &Request{Params: map[string]string{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if profileID != "u1" {
+		t.Errorf("dependent backend should use the parent response: %s", profileID)
+	}
+	index := map[string]int{}
+	for i, e := range events {
+		index[e] = i
+	}
+	if len(events) != 4 || index["profile"] < index["users"] || index["summary"] < index["orders"] || index["summary"] < index["profile"] {
+		t.Errorf("unexpected order: %v", events)
+	}
+	expected := map[string]interface{}{"id": "u1", "orders": 3.0, "profile": "p-u1", "summary": true}
+	if !res.IsComplete || !reflect.DeepEqual(res.Data, expected) {
+		t.Errorf("unexpected response: %+v", res)
+	}
+}
+
+func TestDAGMerge_failurePropagation(t *testing.T) {
+	var called []string
+	var mu sync.Mutex
+	backend := func(name string, err error) Proxy {
+		return func(_ context.Context, _ *Request) (*Response, error) {
+			mu.Lock()
+			called = append(called, name)
+			mu.Unlock()
+			if err != nil {
+				return nil, err
+			}
+			return &Response{Data: map[string]interface{}{name: true}, IsComplete: true}, nil
+		}
+	}
+
+	eConf := newDAGEndpoint()
+	eConf.Backend[2].Fallback = map[string]interface{}{"profile": "none"}
+	p := NewMergeDataChain(eConf)(
+		backend("users", errors.New("connection refused")),
+		backend("orders", nil),
+		backend("profile", nil),
+		backend("summary", nil))
+	res, err := p(context.Background(), &Request{Params: map[string]string{}})
+
+	sort.Strings(called)
+	if !reflect.DeepEqual(called, []string{"orders", "users"}) {
+		t.Errorf("dependents of the failed backend should not be called: %v", called)
+	}
+	// 선행 Backend 실패는 의존 Backend 까지 전파되고, 의존 Backend의 Fallback은 적용
+	expected := map[string]interface{}{"orders": true, "profile": "none"}
+	if res == nil || res.IsComplete || !reflect.DeepEqual(res.Data, expected) {
+		t.Errorf("unexpected response: %+v", res)
+	}
+	me, ok := err.(mergeError)
+	if !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var failed []int
+	for _, be := range me.BackendErrors() {
+		failed = append(failed, be.Backend)
+		if be.Backend > 1 && be.Message != errDependencyFailed.Error() {
+			t.Errorf("backend[%d] should fail by dependency: %s", be.Backend, be.Message)
+		}
+	}
+	if !reflect.DeepEqual(failed, []int{0, 2, 3}) {
+		t.Errorf("unexpected failed backends: %v", failed)
+	}
+
+	// 필수 Backend가 의존 관계로 실패한 경우는 Endpoint 전체 실패
+	eConf.Backend[3].Required = true
+	p = NewMergeDataChain(eConf)(
+		backend("users", errors.New("connection refused")),
+		backend("orders", nil),
+		backend("profile", nil),
+		backend("summary", nil))
+	if res, err := p(context.Background(), &Request{Params: map[string]string{}}); res != nil || err == nil {
+		t.Errorf("required dependent failure should fail the endpoint: %+v, %v", res, err)
+	}
+}
+
+// bodyEchoProxy - 전달된 Request Body를 응답 데이터로 반환하는 Proxy
+func bodyEchoProxy(key string) Proxy {
+	return func(_ context.Context, req *Request) (*Response, error) {
+		if req.Body == nil {
+			return &Response{Data: map[string]interface{}{key: nil}, IsComplete: true}, nil
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &Response{Data: map[string]interface{}{key: string(body)}, IsComplete: true}, nil
+	}
+}
+
+// newReplicateEndpoint - 지정한 Merging 설정으로 Request Body를 복제하는 Endpoint 설정과 Backend 별 Proxy 구성
+func newReplicateEndpoint(mw config.MWConfig) (*config.EndpointConfig, []Proxy) {
+	eConf := &config.EndpointConfig{Endpoint: "/replicate", Method: http.MethodPost, Timeout: time.Second, Middleware: newProxyMiddleware(mw)}
+	proxies := []Proxy{}
+	for _, key := range []string{"a", "b", "c"} {
+		bConf := &config.BackendConfig{URLPattern: "/" + key, Method: http.MethodPost}
+		eConf.Backend = append(eConf.Backend, bConf)
+		// Backend 호출마다 Request Builder에서 복제한 Request 사용
+		proxies = append(proxies, NewRequestBuilderChain(bConf)(bodyEchoProxy(key)))
+	}
+	return eConf, proxies
+}
+
+func newPostRequest(body string) *Request {
+	return &Request{Method: http.MethodPost, Params: map[string]string{}, Headers: map[string][]string{}, Body: ioutil.NopCloser(strings.NewReader(body))}
+}
+
+func TestNewMergeDataChain_replicateBody(t *testing.T) {
+	body := `{"name":"vm"}`
+
+	for _, mode := range []string{"parallel", sequentialKey, dagKey} {
+		eConf, proxies := newReplicateEndpoint(config.MWConfig{mode: true, replicateBodyKey: true})
+		res, err := NewMergeDataChain(eConf)(proxies...)(context.Background(), newPostRequest(body))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", mode, err.Error())
+		}
+		expected := map[string]interface{}{"a": body, "b": body, "c": body}
+		if !res.IsComplete || !reflect.DeepEqual(res.Data, expected) {
+			t.Errorf("%s: body should be sent to every backend: %v", mode, res.Data)
+		}
+	}
+
+	// Body가 없는 요청은 그대로 전달
+	eConf, proxies := newReplicateEndpoint(config.MWConfig{replicateBodyKey: true})
+	res, err := NewMergeDataChain(eConf)(proxies...)(context.Background(), &Request{Method: http.MethodPost, Params: map[string]string{}})
+	if err != nil || !reflect.DeepEqual(res.Data, map[string]interface{}{"a": nil, "b": nil, "c": nil}) {
+		t.Errorf("request without body should be passed: %+v, %v", res, err)
+	}
+}
+
+func TestNewMergeDataChain_replicateBodyLimit(t *testing.T) {
+	var calls int32
+	next := func(_ context.Context, _ *Request) (*Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &Response{IsComplete: true}, nil
+	}
+	eConf, _ := newReplicateEndpoint(config.MWConfig{replicateBodyKey: true, "max_body_size": 8})
+
+	// 최대 크기를 초과한 Body는 Backend 호출 없이 413 처리
+	if _, err := NewMergeDataChain(eConf)(next, next, next)(context.Background(), newPostRequest(`{"name":"vm"}`)); err != errBodyTooLarge || calls != 0 {
+		t.Errorf("too large body should be rejected: %v, calls: %d", err, calls)
+	}
+	if _, err := NewMergeDataChain(eConf)(next, next, next)(context.Background(), newPostRequest(`{"a":1}`)); err != nil || calls != 3 {
+		t.Errorf("body within the limit should be sent: %v, calls: %d", err, calls)
+	}
+}
+
+func TestIsBodyReplicationEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		mw       config.MWConfig
+		expected bool
+	}{
+		{mw: newProxyMiddleware(config.MWConfig{replicateBodyKey: true}), expected: true},
+		{mw: newProxyMiddleware(config.MWConfig{replicateBodyKey: false})},
+		{mw: newProxyMiddleware(config.MWConfig{replicateBodyKey: "true"})},
+		{mw: config.MWConfig{}},
+	} {
+		if got := IsBodyReplicationEnabled(&config.EndpointConfig{Middleware: tc.mw}); got != tc.expected {
+			t.Errorf("%v: unexpected result %v", tc.mw, got)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Package proxy - Backend 요청의 복제본을 Shadow Host로 비동기 전달 (Traffic Mirroring) 하는 처리 패키지
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// Backend 별로 동시에 처리할 수 있는 Shadow 요청의 기본 최대 수
+	defaultMirrorConcurrency = 100
+)
+
+// ===== [ Types ] =====
+
+type (
+	// MirrorResult - Mirror (Shadow) 요청 처리 결과 정보 구조
+	MirrorResult struct {
+		// Mirror 설정된 Backend 식별 명 (URLPattern)
+		Name string
+		// Shadow 요청 처리 시간
+		Duration time.Duration
+		// Shadow 응답 상태 코드 (오류인 경우는 오류에 해당하는 상태 코드)
+		StatusCode int
+		// Shadow 요청 처리 오류
+		Err error
+		// Primary 응답과 비교 수행 여부 (diff 설정이고 양쪽 모두 정상 응답 데이터가 있는 경우)
+		Compared bool
+		// Primary 응답과 데이터가 일치하는지 여부
+		Matched bool
+		// 처리 중인 Shadow 요청 수가 최대 값에 도달해서 복제를 생략했는지 여부
+		Dropped bool
+	}
+
+	// MirrorReporter - Mirror (Shadow) 요청 처리 결과를 수집하는 함수 형식 (Metrics 연계용)
+	MirrorReporter func(MirrorResult)
+
+	// detachedContext - Request Context의 값은 유지하고 취소/종료는 전파되지 않는 Context 구조 (Fire-and-forget 처리용)
+	detachedContext struct {
+		parent context.Context
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// Deadline - 종료 시간 없음
+func (dc detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+// Done - 취소 없음
+func (dc detachedContext) Done() <-chan struct{} { return nil }
+
+// Err - 오류 없음
+func (dc detachedContext) Err() error { return nil }
+
+// Value - 원본 Context의 값 반환
+func (dc detachedContext) Value(key interface{}) interface{} { return dc.parent.Value(key) }
+
+// ===== [ Private Functions ] =====
+
+// newMirrorBackendConfig - 지정한 Backend 설정의 Mirror 설정을 적용한 Shadow Backend 설정 생성
+func newMirrorBackendConfig(bConf *config.BackendConfig) *config.BackendConfig {
+	mConf := *bConf
+	mConf.Hosts = bConf.Mirror.Hosts
+	mConf.BalanceMode = bConf.Mirror.BalanceMode
+	mConf.Timeout = bConf.Mirror.Timeout
+	mConf.Mirror = nil
+	mConf.Mock = nil
+	return &mConf
+}
+
+// mirrorConcurrency - 지정한 Mirror 설정에서 동시에 처리할 수 있는 Shadow 요청의 최대 수 반환 (미 지정시 기본 값)
+func mirrorConcurrency(mConf *config.MirrorConfig) int {
+	if mConf.MaxConcurrency > 0 {
+		return mConf.MaxConcurrency
+	}
+	return defaultMirrorConcurrency
+}
+
+// maxBodySize - 지정한 Backend 설정에서 Request Body 보관시 허용할 최대 크기 반환 (미 지정시 기본 값)
+func maxBodySize(bConf *config.BackendConfig) int64 {
+	if bConf.MaxBodySize > 0 {
+		return bConf.MaxBodySize
+	}
+	return config.DefaultMaxBodySize
+}
+
+// bufferRequestBody - Primary와 Shadow 요청에서 모두 사용할 수 있도록 지정한 최대 크기 내에서 Request Body를 메모리에 보관 (최대 크기 초과시는 Mirror 불가로 false 반환)
+func bufferRequestBody(req *Request, maxSize int64) (bool, error) {
+	if req.Body == nil {
+		return true, nil
+	}
+	if _, ok := req.Body.(*bufferedBody); ok {
+		return true, nil
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(req.Body, maxSize+1))
+	if err != nil {
+		req.Body.Close()
+		return false, err
+	}
+	if int64(len(data)) > maxSize {
+		// 이미 읽은 데이터와 남은 Body를 연결해서 Primary 요청은 그대로 처리
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), req.Body), req.Body}
+		return false, nil
+	}
+	req.Body.Close()
+	req.Body = newBufferedBody(data)
+	return true, nil
+}
+
+// mirrorRequest - Shadow 요청을 처리하고 응답을 폐기한 후에 결과 (Primary 응답과의 비교 포함) 를 Reporter로 전달
+func mirrorRequest(ctx context.Context, name string, shadow Proxy, req *Request, timeout time.Duration, primary <-chan *Response, report MirrorReporter) {
+	localCtx, cancel := newTimeoutContext(ctx, timeout)
+	defer cancel()
+
+	begin := time.Now()
+	res, err := shadow(localCtx, req)
+	result := MirrorResult{Name: name, Duration: time.Since(begin), Err: err}
+
+	if res != nil && res.Io != nil {
+		io.Copy(ioutil.Discard, res.Io)
+	}
+
+	switch {
+	case err != nil:
+		result.StatusCode = errorStatusCode(err)
+	case res != nil && res.Metadata.StatusCode != 0:
+		result.StatusCode = res.Metadata.StatusCode
+	default:
+		result.StatusCode = http.StatusOK
+	}
+
+	if primary != nil {
+		if pr := <-primary; pr != nil && err == nil && res != nil && res.Io == nil {
+			result.Compared = true
+			result.Matched = reflect.DeepEqual(pr.Data, res.Data)
+		}
+	}
+
+	if err != nil {
+		logger.Debugf("[API G/W] Proxy > Mirror request to %s failed: %s", name, err.Error())
+	}
+	if report != nil {
+		report(result)
+	}
+}
+
+// ===== [ Public Functions ] =====
+
+// NewMirrorChain - Backend의 Mirror 설정에 따라 요청 복제본을 지정한 Shadow Proxy로 비동기 전달하고 (응답은 폐기) 처리 결과를 Reporter로 전달하는 Proxy 호출 체인 생성
+// (Retry, Hedge 앞에 구성되어 클라이언트 요청 당 한번만 복제하며, 처리 중인 Shadow 요청 수가 최대 값에 도달한 경우는 복제 생략)
+func NewMirrorChain(bConf *config.BackendConfig, shadow Proxy, report MirrorReporter) CallChain {
+	return func(next ...Proxy) Proxy {
+		if len(next) > 1 {
+			panic(ErrTooManyProxies)
+		}
+		if bConf.Mirror == nil {
+			return next[0]
+		}
+
+		name := bConf.URLPattern
+		diff := bConf.Mirror.Diff
+		timeout := bConf.Mirror.Timeout
+		maxSize := maxBodySize(bConf)
+		slots := make(chan struct{}, mirrorConcurrency(bConf.Mirror))
+
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			ok, err := bufferRequestBody(req, maxSize)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				logger.Warnf("[API G/W] Proxy > Request body too large, skip mirroring to %s", name)
+				return next[0](ctx, req)
+			}
+
+			select {
+			case slots <- struct{}{}:
+			default:
+				logger.Debugf("[API G/W] Proxy > Too many mirror requests in flight, drop mirroring to %s", name)
+				if report != nil {
+					report(MirrorResult{Name: name, Dropped: true})
+				}
+				return next[0](ctx, req)
+			}
+
+			var primaryRes chan *Response
+			if diff {
+				primaryRes = make(chan *Response, 1)
+			}
+			go func(r *Request) {
+				defer func() { <-slots }()
+				mirrorRequest(detachedContext{ctx}, name, shadow, r, timeout, primaryRes, report)
+			}(CloneRequest(req))
+
+			res, err := next[0](ctx, req)
+			if primaryRes != nil {
+				if err == nil && res != nil && res.Io == nil {
+					primaryRes <- &Response{Data: CloneData(res.Data).(map[string]interface{})}
+				} else {
+					primaryRes <- nil
+				}
+			}
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/sd"
+)
+
+func newMirrorBackend(mirror *config.MirrorConfig) *config.BackendConfig {
+	return &config.BackendConfig{
+		URLPattern: "/mirror",
+		Method:     http.MethodGet,
+		Hosts:      []*config.HostConfig{{Host: "http://primary"}},
+		Timeout:    time.Second,
+		Mirror:     mirror,
+	}
+}
+
+func TestMirror_oncePerRequest(t *testing.T) {
+	bConf := newMirrorBackend(&config.MirrorConfig{Hosts: []*config.HostConfig{{Host: "http://shadow"}}, Timeout: time.Second})
+	bConf.Retry = &config.RetryConfig{MaxAttempts: 3, Backoff: time.Millisecond, StatusCodes: []int{http.StatusServiceUnavailable}}
+
+	var primaries, shadows int32
+	bf := func(bc *config.BackendConfig) Proxy {
+		return func(_ context.Context, _ *Request) (*Response, error) {
+			atomic.AddInt32(&primaries, 1)
+			return &Response{Metadata: Metadata{StatusCode: http.StatusServiceUnavailable}}, nil
+		}
+	}
+	shadow := func(bc *config.BackendConfig) Proxy {
+		return func(_ context.Context, _ *Request) (*Response, error) {
+			atomic.AddInt32(&shadows, 1)
+			return &Response{IsComplete: true}, nil
+		}
+	}
+
+	results := make(chan MirrorResult, 3)
+	pf := NewDefaultFactoryWithMirror(bf, shadow, *logging.NewLogger(), sd.GetSubscriber, Reporters{Mirror: func(r MirrorResult) { results <- r }})
+	p := pf.(defaultFactory).newStack(bConf)
+	if _, err := p(context.Background(), &Request{Method: http.MethodGet, Params: map[string]string{}, Headers: map[string][]string{}}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	select {
+	case <-results:
+	case <-time.After(time.Second):
+		t.Fatal("mirror result not reported")
+	}
+	if n := atomic.LoadInt32(&primaries); n != 3 {
+		t.Errorf("unexpected primary calls: %d", n)
+	}
+	if n := atomic.LoadInt32(&shadows); n != 1 {
+		t.Errorf("shadow must be called once per client request, but %d", n)
+	}
+}
+
+func TestMirrorChain_dropWhenSaturated(t *testing.T) {
+	bConf := newMirrorBackend(&config.MirrorConfig{MaxConcurrency: 1})
+
+	release := make(chan struct{})
+	shadow := func(_ context.Context, _ *Request) (*Response, error) {
+		<-release
+		return &Response{IsComplete: true}, nil
+	}
+	primary := func(_ context.Context, _ *Request) (*Response, error) {
+		return &Response{IsComplete: true}, nil
+	}
+
+	results := make(chan MirrorResult, 3)
+	p := NewMirrorChain(bConf, shadow, func(r MirrorResult) { results <- r })(primary)
+	for i := 0; i < 2; i++ {
+		if _, err := p(context.Background(), &Request{}); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+
+	// 첫 번째 Shadow 요청이 처리 중이므로 두 번째 요청은 복제 생략
+	if r := <-results; !r.Dropped {
+		t.Errorf("second mirror request must be dropped: %+v", r)
+	}
+	close(release)
+	if r := <-results; r.Dropped {
+		t.Errorf("first mirror request must not be dropped: %+v", r)
+	}
+
+}
@@ -0,0 +1,101 @@
+// Package proxy - Host 대신 설정된 고정 응답을 반환하는 Mock Backend 처리 패키지
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/transport/http/client"
+)
+
+// ===== [ Constants and Variables ] =====
+
+var (
+	errMockUnavailable = core.NewWrappedError(http.StatusInternalServerError, "mock response is not available", nil)
+)
+
+// ===== [ Types ] =====
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+
+// loadMockBody - Mock 설정의 Body (문자열은 그대로, 그 외는 JSON 변환) 또는 File 내용과 기본 Content-Type 반환
+func loadMockBody(mc *config.MockConfig) ([]byte, string, error) {
+	if mc.File != "" {
+		body, err := ioutil.ReadFile(mc.File)
+		return body, "", err
+	}
+
+	switch t := mc.Body.(type) {
+	case nil:
+		return []byte{}, "", nil
+	case string:
+		return []byte(t), "text/plain; charset=utf-8", nil
+	default:
+		body, err := core.JSONMarshal(t)
+		return body, "application/json", err
+	}
+}
+
+// newMockHTTPRequestExecutor - Backend 호출 대신 Mock 설정의 고정 응답을 반환하는 HTTPRequestExecutor 생성
+func newMockHTTPRequestExecutor(bConf *config.BackendConfig) client.HTTPRequestExecutor {
+	mc := bConf.Mock
+	body, contentType, err := loadMockBody(mc)
+	if err != nil {
+		logger.Errorf("[API G/W] Proxy > Can not load the mock response for %s: %s", bConf.URLPattern, err.Error())
+		return func(_ context.Context, _ *http.Request) (*http.Response, error) {
+			return nil, errMockUnavailable
+		}
+	}
+
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		headers := make(http.Header, len(mc.Headers)+2)
+		if contentType != "" {
+			headers.Set("Content-Type", contentType)
+		}
+		for k, v := range mc.Headers {
+			headers.Set(k, v)
+		}
+		headers.Set("Content-Length", strconv.Itoa(len(body)))
+
+		return &http.Response{
+			Status:        strconv.Itoa(mc.StatusCode) + " " + http.StatusText(mc.StatusCode),
+			StatusCode:    mc.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        headers,
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+}
+
+// ===== [ Public Functions ] =====
+
+// NewMockBackendFactory - Host 없이 Mock 설정이 지정된 Backend는 고정 응답을 반환하는 Proxy를 사용하고, 그 외는 지정한 BackendFactory를 사용하는 BackendFactory 생성
+// (고정 응답도 Backend의 Encoding, Status 처리, Formatter 등을 동일하게 적용)
+func NewMockBackendFactory(next BackendFactory) BackendFactory {
+	return func(bConf *config.BackendConfig) Proxy {
+		if !bConf.IsMock() {
+			return next(bConf)
+		}
+
+		p := NewHTTPProxyWithHTTPExecutor(bConf, newMockHTTPRequestExecutor(bConf), bConf.Decoder)
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			// Load Balancer를 거치지 않으므로 Backend 경로로 URL 구성
+			if req.URL == nil {
+				r := req.Clone()
+				r.URL = &url.URL{Path: r.Path}
+				req = &r
+			}
+			return p(ctx, req)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package proxy
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
@@ -75,6 +76,14 @@ func (rcw readCloserWrapper) closeOnCancel() {
 
 // ===== [ Private Functions ] =====
 
+// newTimeoutContext - 지정한 시간이 있으면 Timeout이 적용된 Context, 없으면 취소만 가능한 Context 생성
+func newTimeoutContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
 // ===== [ Public Functions ] =====
 
 // EmptyChain - 테스트나 오류 처리를 위한 빈 Proxy Chain 생성
@@ -0,0 +1,135 @@
+// Package proxy - JMESPath 표현식을 이용해서 Response 데이터를 재 구성하는 Query 처리 패키지
+package proxy
+
+import (
+	"context"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/jmespath/go-jmespath"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	queryKey = "query"
+
+	// Query 결과가 객체나 배열이 아닌 경우에 사용할 키
+	queryValueKey = "value"
+)
+
+// ===== [ Types ] =====
+
+// queryFormatter - JMESPath 표현식을 사용해서 Response를 Format 처리하는 구조 정의
+type queryFormatter struct {
+	Target string
+	Prefix string
+	Query  *jmespath.JMESPath
+}
+
+// ===== [ Implementations ] =====
+
+// Format - JMESPath 표현식을 활용하는 EntityFormatter 구현
+func (qf queryFormatter) Format(entity Response) Response {
+	// Target 처리
+	if qf.Target != "" {
+		extractTarget(qf.Target, &entity)
+	}
+
+	applyQuery(qf.Query, &entity)
+
+	if qf.Prefix != "" {
+		entity.Data = map[string]interface{}{qf.Prefix: entity.Data}
+	}
+	return entity
+}
+
+// ===== [ Private Functions ] =====
+
+// applyQuery - 지정한 Response 데이터에 JMESPath 표현식을 적용하고 결과로 데이터 교체 (Collection Wrapping 된 경우는 Array 기준으로 처리)
+func applyQuery(query *jmespath.JMESPath, entity *Response) {
+	var input interface{} = entity.Data
+	wrapping, isWrapped := entity.Data[core.WrappingTag]
+	if isWrapped {
+		input = entity.Data[core.CollectionTag]
+	}
+
+	result, err := query.Search(input)
+	if err != nil {
+		logger.Warnf("[API G/W] Proxy > Failed to apply query: %s", err.Error())
+		entity.IsComplete = false
+		return
+	}
+
+	switch t := result.(type) {
+	case map[string]interface{}:
+		entity.Data = t
+	case []interface{}:
+		entity.Data = map[string]interface{}{core.CollectionTag: t}
+		if isWrapped {
+			entity.Data[core.WrappingTag] = wrapping
+		}
+	case nil:
+		entity.Data = map[string]interface{}{}
+	default:
+		entity.Data = map[string]interface{}{queryValueKey: t}
+	}
+}
+
+// getQuery - 지정한 Middleware 설정 ("mw-proxy.query") 의 JMESPath 표현식을 Compile 해서 반환 (미 지정 또는 잘못된 표현식인 경우는 nil)
+func getQuery(mwConf config.MWConfig, name string) *jmespath.JMESPath {
+	v, ok := mwConf[MWNamespace]
+	if !ok {
+		return nil
+	}
+	e, ok := v.(config.MWConfig)
+	if !ok {
+		return nil
+	}
+	expr, ok := e[queryKey].(string)
+	if !ok || expr == "" {
+		return nil
+	}
+
+	query, err := jmespath.Compile(expr)
+	if err != nil {
+		logger.Warnf("[API G/W] Proxy > Invalid query '%s' on %s: %s", expr, name, err.Error())
+		return nil
+	}
+	return query
+}
+
+// newQueryFormatter - 지정된 BackendConfig 기준으로 JMESPath 표현식을 활용하는 Formatter 생성
+func newQueryFormatter(bConf *config.BackendConfig) EntityFormatter {
+	query := getQuery(bConf.Middleware, bConf.URLPattern)
+	if query == nil {
+		return nil
+	}
+	return &queryFormatter{
+		Target: bConf.Target,
+		Prefix: bConf.Group,
+		Query:  query,
+	}
+}
+
+// ===== [ Public Functions ] =====
+
+// NewQueryChain - Endpoint 설정의 JMESPath 표현식 ("mw-proxy.query") 을 Backend 처리 (Merging 포함) 결과에 적용하는 Proxy Call chain 생성
+func NewQueryChain(eConf *config.EndpointConfig) CallChain {
+	query := getQuery(eConf.Middleware, eConf.Endpoint)
+	if query == nil {
+		return EmptyChain
+	}
+	return func(next ...Proxy) Proxy {
+		if len(next) > 1 {
+			panic(ErrTooManyProxies)
+		}
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			res, err := next[0](ctx, req)
+			if res != nil && res.Io == nil {
+				applyQuery(query, res)
+			}
+			return res, err
+		}
+	}
+}
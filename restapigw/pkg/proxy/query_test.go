@@ -0,0 +1,131 @@
+package proxy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/jmespath/go-jmespath"
+)
+
+// querySample - 테스트 응답 데이터 생성 (Query 처리가 데이터를 교체하므로 매번 생성)
+func querySample() map[string]interface{} {
+	return map[string]interface{}{
+		"user":  map[string]interface{}{"id": 1.0, "name": "x"},
+		"items": []interface{}{map[string]interface{}{"id": 1.0, "name": "a"}, map[string]interface{}{"id": 2.0, "name": "b"}},
+		"count": 2.0,
+	}
+}
+
+func TestApplyQuery(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		query    string
+		data     map[string]interface{}
+		expected string
+		failed   bool
+	}{
+		{name: "object", query: "user", data: querySample(), expected: `{"id":1,"name":"x"}`},
+		{name: "projection", query: "{id: user.id, names: items[].name}", data: querySample(), expected: `{"id":1,"names":["a","b"]}`},
+		// 배열 결과는 Collection으로 처리
+		{name: "array", query: "items[].name", data: querySample(), expected: `{"collection":["a","b"]}`},
+		// 객체나 배열이 아닌 결과는 "value" 키로 처리
+		{name: "scalar", query: "count", data: querySample(), expected: `{"value":2}`},
+		{name: "missing", query: "unknown", data: querySample(), expected: `{}`},
+		// 실행 오류는 원본 데이터를 유지하고 불완전 처리
+		{name: "runtime error", query: "abs(user)", data: querySample(), expected: `{"user":{"id":1,"name":"x"},"items":[{"id":1,"name":"a"},{"id":2,"name":"b"}],"count":2}`, failed: true},
+	} {
+		res := &Response{Data: tc.data, IsComplete: true}
+		applyQuery(jmespath.MustCompile(tc.query), res)
+		assertJSON(t, tc.name, res.Data, tc.expected)
+		if res.IsComplete == tc.failed {
+			t.Errorf("%s: unexpected complete: %v", tc.name, res.IsComplete)
+		}
+	}
+}
+
+func TestApplyQuery_wrapping(t *testing.T) {
+	items := querySample()["items"]
+
+	// Wrapping 된 Collection은 Array 기준으로 처리하고 Wrapping 유지
+	res := &Response{Data: map[string]interface{}{core.CollectionTag: items, core.WrappingTag: true}, IsComplete: true}
+	applyQuery(jmespath.MustCompile("[?id > `1`]"), res)
+	if res.Data[core.WrappingTag] != true {
+		t.Errorf("wrapping should be kept: %v", res.Data)
+	}
+	assertJSON(t, "wrapped array", res.Data[core.CollectionTag], `[{"id":2,"name":"b"}]`)
+
+	// 객체 결과는 Wrapping 해제
+	res = &Response{Data: map[string]interface{}{core.CollectionTag: items, core.WrappingTag: true}, IsComplete: true}
+	applyQuery(jmespath.MustCompile("[0]"), res)
+	assertJSON(t, "wrapped object", res.Data, `{"id":1,"name":"a"}`)
+
+	// Wrapping 되지 않은 Collection은 객체 기준으로 처리
+	res = &Response{Data: map[string]interface{}{core.CollectionTag: items}, IsComplete: true}
+	applyQuery(jmespath.MustCompile("collection[].id"), res)
+	assertJSON(t, "not wrapped", res.Data, `{"collection":[1,2]}`)
+}
+
+func TestQueryFormatter(t *testing.T) {
+	bConf := &config.BackendConfig{
+		URLPattern: "/query",
+		Target:     "data",
+		Group:      "result",
+		Middleware: newProxyMiddleware(config.MWConfig{queryKey: "items[?id == `2`].name"}),
+	}
+	f := NewEntityFormatter(bConf)
+	if _, ok := f.(*queryFormatter); !ok {
+		t.Fatalf("query formatter should be used: %T", f)
+	}
+
+	// Target 추출 후 Query 적용하고 Group 처리
+	res := f.Format(Response{Data: map[string]interface{}{"data": querySample()}, IsComplete: true})
+	assertJSON(t, "formatter", res.Data, `{"result":{"collection":["b"]}}`)
+
+	// 잘못된 표현식은 Query 처리하지 않음
+	bConf.Middleware = newProxyMiddleware(config.MWConfig{queryKey: "items[?"})
+	if f := newQueryFormatter(bConf); f != nil {
+		t.Errorf("invalid query should be ignored: %T", f)
+	}
+}
+
+func TestNewQueryChain(t *testing.T) {
+	eConf := &config.EndpointConfig{
+		Endpoint:   "/query",
+		Middleware: newProxyMiddleware(config.MWConfig{queryKey: "{total: count, first: items[0].name}"}),
+	}
+
+	next := func(_ context.Context, _ *Request) (*Response, error) {
+		return &Response{Data: querySample(), IsComplete: true}, nil
+	}
+	res, err := NewQueryChain(eConf)(next)(context.Background(), &Request{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	assertJSON(t, "endpoint query", res.Data, `{"total":2,"first":"a"}`)
+
+	// Streaming 응답은 Query 처리하지 않음
+	stream := func(_ context.Context, _ *Request) (*Response, error) {
+		return &Response{Data: querySample(), Io: strings.NewReader("stream")}, nil
+	}
+	if res, _ := NewQueryChain(eConf)(stream)(context.Background(), &Request{}); res.Data["count"] != 2.0 {
+		t.Errorf("stream response should not be changed: %v", res.Data)
+	}
+
+	// 실패한 경우는 오류와 결과 그대로 전달
+	failed := func(_ context.Context, _ *Request) (*Response, error) {
+		return nil, errDependencyFailed
+	}
+	if res, err := NewQueryChain(eConf)(failed)(context.Background(), &Request{}); res != nil || err != errDependencyFailed {
+		t.Errorf("error should be passed through: %+v, %v", res, err)
+	}
+
+	// 설정이 없는 경우는 Query 처리하지 않음
+	eConf.Middleware = config.MWConfig{}
+	res, _ = NewQueryChain(eConf)(next)(context.Background(), &Request{})
+	if res.Data["count"] != 2.0 {
+		t.Errorf("response should not be changed without query: %v", res.Data)
+	}
+}
@@ -26,6 +26,11 @@ func (cr *combinerRegister) GetResponseCombiner(name string) (ResponseCombiner,
 	return cr.fallback, ok
 }
 
+// Register - 지정한 이름으로 Response Combiner 등록
+func (cr *combinerRegister) Register(name string, rc ResponseCombiner) {
+	cr.data.Register(name, rc)
+}
+
 // ===== [ Private Functions ] =====
 
 // newCombinerRegister - 지정된 데이터로 구성되는 Response Merging 처리용 Combiner 생성
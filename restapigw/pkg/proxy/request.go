@@ -2,14 +2,27 @@ package proxy
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 )
 
 // ===== [ Constants and Variables ] =====
 
 // ===== [ Types ] =====
 
+// bufferedBody - 여러 Backend에 재 전송할 수 있도록 메모리에 보관된 Request Body 구조
+type bufferedBody struct {
+	*bytes.Reader
+	data []byte
+}
+
 // Request - Proxy 구간에서 사용할 Request 구조
 type Request struct {
 	IsBypass bool
@@ -24,6 +37,14 @@ type Request struct {
 
 // ===== [ Implementations ] =====
 
+// Close - io.ReadCloser 인터페이스 구현 (메모리 데이터이므로 처리 없음)
+func (bb *bufferedBody) Close() error { return nil }
+
+// replay - 처음부터 다시 읽을 수 있는 동일한 데이터의 Body 반환
+func (bb *bufferedBody) replay() *bufferedBody {
+	return newBufferedBody(bb.data)
+}
+
 // GeneratePath - Params의 정보를 이용해서 URLPattern에 존재하는 파라미터 설정을 실제 값으로 변경
 func (r *Request) GeneratePath(urlPattern string) {
 	// 전달된 Path Parameter가 존재하지 않는 경우
@@ -42,15 +63,50 @@ func (r *Request) GeneratePath(urlPattern string) {
 	r.Path = string(buff)
 }
 
-// Clone - Request 복제 (단, Thread-safe가 아니므로 Thread-safe가 필요한 경우는 "CloneRequest" 사용)
+// ApplyTemplates - Params의 정보를 이용해서 Backend 설정의 Header, Query String, Body 템플릿을 실제 값으로 변경하고 Request에 설정
+func (r *Request) ApplyTemplates(bConf *config.BackendConfig) {
+	if len(bConf.RequestHeaders) > 0 {
+		r.Headers = CloneMapValues(r.Headers)
+		for k, v := range bConf.RequestHeaders {
+			r.Headers[http.CanonicalHeaderKey(k)] = []string{replaceParams(v, r.Params, nil)}
+		}
+	}
+
+	if len(bConf.RequestQuery) > 0 {
+		r.Query = CloneMapValues(r.Query)
+		for k, v := range bConf.RequestQuery {
+			r.Query[k] = []string{replaceParams(v, r.Params, nil)}
+		}
+	}
+
+	if bConf.RequestBody != "" {
+		// JSON 문자열에 포함될 수 있도록 값을 Escape 처리
+		body := replaceParams(bConf.RequestBody, r.Params, escapeJSONString)
+		r.Body = ioutil.NopCloser(strings.NewReader(body))
+
+		if len(bConf.RequestHeaders) == 0 {
+			r.Headers = CloneMapValues(r.Headers)
+		}
+		r.Headers["Content-Length"] = []string{strconv.Itoa(len(body))}
+		if _, ok := r.Headers["Content-Type"]; !ok {
+			r.Headers["Content-Type"] = []string{"application/json"}
+		}
+	}
+}
+
+// Clone - Request 복제 (단, Thread-safe가 아니므로 Thread-safe가 필요한 경우는 "CloneRequest" 사용, 보관된 Body는 복제본 별로 재 전송)
 func (r *Request) Clone() Request {
+	body := r.Body
+	if bb, ok := body.(*bufferedBody); ok {
+		body = bb.replay()
+	}
 	return Request{
 		IsBypass: r.IsBypass,
 		Method:   r.Method,
 		URL:      r.URL,
 		Query:    r.Query,
 		Path:     r.Path,
-		Body:     r.Body,
+		Body:     body,
 		Params:   r.Params,
 		Headers:  r.Headers,
 	}
@@ -58,6 +114,34 @@ func (r *Request) Clone() Request {
 
 // ===== [ Private Functions ] =====
 
+// replaceParams - 지정한 템플릿에 존재하는 "{{.Xxx}}" 파라미터를 Params의 값으로 변경 (escape 함수 지정시 값에 적용, 값을 찾을 수 없는 {{.RespN_xxx}} 파라미터는 빈 값으로 변경)
+func replaceParams(tmpl string, params map[string]string, escape func(string) string) string {
+	if !strings.Contains(tmpl, "{{.") {
+		return tmpl
+	}
+	for k, v := range params {
+		if escape != nil {
+			v = escape(v)
+		}
+		tmpl = strings.Replace(tmpl, "{{."+k+"}}", v, -1)
+	}
+	return reMergeKey.ReplaceAllString(tmpl, "")
+}
+
+// newBufferedBody - 지정한 데이터를 재 전송 가능한 Body로 구성
+func newBufferedBody(data []byte) *bufferedBody {
+	return &bufferedBody{Reader: bytes.NewReader(data), data: data}
+}
+
+// escapeJSONString - 지정한 문자열을 JSON 문자열 내부에 사용할 수 있도록 Escape 처리 (앞뒤 따옴표 제외)
+func escapeJSONString(v string) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	return string(b[1 : len(b)-1])
+}
+
 // ===== [ Public Functions ] =====
 
 // CloneMapValues - map[string][]string 형식의 정보를 복제
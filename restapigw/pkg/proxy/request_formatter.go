@@ -0,0 +1,181 @@
+// Package proxy - Backend로 전달할 Request Body에 대한 Whitelist, Rename, Set, Template 등의 변환 처리를 수행하는 Formatter 패키지
+package proxy
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core/flatmap/tree"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	requestTransformKey = "request_transform"
+)
+
+var (
+	errInvalidRequestBody = core.NewWrappedError(http.StatusBadRequest, "invalid request body for transformation", nil)
+)
+
+// ===== [ Types ] =====
+
+// requestOp - Request Body 변환을 위한 설정 구조 정의
+type requestOp struct {
+	Type string
+	Args [][]string
+	// set 처리에 사용할 값, template 처리에 사용할 문자열 템플릿
+	Value interface{}
+}
+
+// requestBodyFormatter - Flatmap을 사용해서 JSON Request Body를 변환 처리하는 구조 정의
+type requestBodyFormatter struct {
+	Ops []requestOp
+	// Request Body 최대 크기
+	MaxSize int64
+}
+
+// RequestFormatter - Backend로 전달할 Request를 Format 처리하는 인터페이스 정의 (EntityFormatter의 Request 대응)
+type RequestFormatter interface {
+	Format(*Request) error
+}
+
+// ===== [ Implementations ] =====
+
+// Format - JSON Request Body를 Decode 하고 설정된 처리를 적용한 후에 다시 Encode 해서 Request에 설정 (Content-Length 재 설정, Body가 없는 요청은 변환하지 않음)
+func (rf requestBodyFormatter) Format(req *Request) error {
+	if req.Body == nil {
+		return nil
+	}
+	raw, err := ioutil.ReadAll(io.LimitReader(req.Body, rf.MaxSize+1))
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+	if int64(len(raw)) > rf.MaxSize {
+		return errBodyTooLarge
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		req.Body = newBufferedBody(raw)
+		return nil
+	}
+
+	data := map[string]interface{}{}
+	if err := core.JSONDecode(bytes.NewReader(raw), &data); err != nil {
+		return errInvalidRequestBody
+	}
+
+	t, err := tree.New(data)
+	if err != nil {
+		return errInvalidRequestBody
+	}
+	for _, op := range rf.Ops {
+		switch op.Type {
+		case "whitelist":
+			// whitelist - 지정한 경로들만 유지
+			t.Keep(op.Args...)
+		case "del":
+			for _, path := range op.Args {
+				t.Del(path)
+			}
+		case "move":
+			t.Move(op.Args[0], op.Args[1])
+		case "rename":
+			t.Rename(op.Args[0], strings.Join(op.Args[1], "."))
+		case "set":
+			t.Set(op.Args[0], op.Value)
+		case "template":
+			// template - Endpoint 파라미터 ("{{.Xxx}}") 를 적용한 문자열 설정
+			if tmpl, ok := op.Value.(string); ok {
+				t.Set(op.Args[0], replaceParams(tmpl, req.Params, nil))
+			}
+		default:
+		}
+	}
+	if data, _ = t.Get([]string{}).(map[string]interface{}); data == nil {
+		data = map[string]interface{}{}
+	}
+
+	body, err := core.JSONMarshal(data)
+	if err != nil {
+		return err
+	}
+	req.Body = newBufferedBody(body)
+	req.Headers = CloneMapValues(req.Headers)
+	req.Headers["Content-Length"] = []string{strconv.Itoa(len(body))}
+	req.Headers["Content-Type"] = []string{"application/json"}
+	return nil
+}
+
+// ===== [ Private Functions ] =====
+
+// requestTemplateValues - 지정한 Backend 설정의 Request Body 변환 (request_transform) 에 지정된 template 값 목록 반환
+func requestTemplateValues(bConf *config.BackendConfig) []string {
+	values := []string{}
+	if v, ok := bConf.Middleware[MWNamespace]; ok {
+		if e, ok := v.(config.MWConfig); ok {
+			vs, _ := e[requestTransformKey].([]interface{})
+			for _, v := range vs {
+				if m, ok := v.(config.MWConfig); ok && m["type"] == "template" {
+					if tmpl, ok := m["value"].(string); ok {
+						values = append(values, tmpl)
+					}
+				}
+			}
+		}
+	}
+	return values
+}
+
+// ===== [ Public Functions ] =====
+
+// NewRequestFormatter - 지정된 Backend 설정 ("mw-proxy.request_transform") 을 기준으로 Request Body 처리에 사용할 RequestFormatter 생성 (설정이 없는 경우는 nil)
+func NewRequestFormatter(bConf *config.BackendConfig) RequestFormatter {
+	v, ok := bConf.Middleware[MWNamespace]
+	if !ok {
+		return nil
+	}
+	e, ok := v.(config.MWConfig)
+	if !ok {
+		return nil
+	}
+	vs, ok := e[requestTransformKey].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	ops := []requestOp{}
+	for _, v := range vs {
+		m, ok := v.(config.MWConfig)
+		if !ok {
+			continue
+		}
+		op := requestOp{Value: m["value"]}
+		if op.Type, ok = m["type"].(string); !ok {
+			continue
+		}
+		if args, ok := m["args"].([]interface{}); ok {
+			op.Args = make([][]string, len(args))
+			for k, arg := range args {
+				if t, ok := arg.(string); ok {
+					op.Args[k] = strings.Split(t, ".")
+				}
+			}
+		}
+		if len(op.Args) < config.RequestTransformOps.MinArgs(op.Type) {
+			// 설정 검증을 거치지 않은 경우의 인자 부족은 무시
+			continue
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return requestBodyFormatter{Ops: ops, MaxSize: maxBodySize(bConf)}
+}
@@ -0,0 +1,173 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"go/build"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+)
+
+// newTransformBackend - 지정한 request_transform 처리들을 설정한 Backend 설정 생성
+func newTransformBackend(ops ...config.MWConfig) *config.BackendConfig {
+	vs := make([]interface{}, len(ops))
+	for i, op := range ops {
+		vs[i] = op
+	}
+	return &config.BackendConfig{URLPattern: "/transform", Method: http.MethodPost, Middleware: newProxyMiddleware(config.MWConfig{requestTransformKey: vs})}
+}
+
+func transformOpConf(opType string, value interface{}, args ...interface{}) config.MWConfig {
+	return config.MWConfig{"type": opType, "args": args, "value": value}
+}
+
+// skipWithoutMapEncoding - reflect2 v1.0.2 미만을 사용하는 jsoniter 는 Go 1.18 이상에서 Map 처리가 불가능하므로 테스트 생략
+func skipWithoutMapEncoding(t *testing.T) {
+	t.Helper()
+	if !core.ContainsString(build.Default.ReleaseTags, "go1.18") {
+		return
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range bi.Deps {
+			if dep.Path == "github.com/modern-go/reflect2" && dep.Version < "v1.0.2" {
+				t.Skipf("jsoniter can not handle maps with %s and reflect2 %s", runtime.Version(), dep.Version)
+			}
+		}
+	}
+}
+
+func newBodyRequest(body string) *Request {
+	return &Request{
+		Params:  map[string]string{"Ns": "ns-01"},
+		Headers: map[string][]string{"Content-Length": {strconv.Itoa(len(body))}},
+		Body:    ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestRequestFormatter_ops(t *testing.T) {
+	skipWithoutMapEncoding(t)
+	body := `{"name":"vm","password":"secret","spec":{"cpu":2,"mem":4},"tags":["a"]}`
+
+	for _, tc := range []struct {
+		name     string
+		ops      []config.MWConfig
+		expected string
+	}{
+		{name: "whitelist", ops: []config.MWConfig{transformOpConf("whitelist", nil, "name", "spec.cpu")}, expected: `{"name":"vm","spec":{"cpu":2}}`},
+		{name: "del", ops: []config.MWConfig{transformOpConf("del", nil, "password", "spec.mem")}, expected: `{"name":"vm","spec":{"cpu":2},"tags":["a"]}`},
+		{name: "move", ops: []config.MWConfig{transformOpConf("move", nil, "spec.cpu", "vCPU")}, expected: `{"name":"vm","password":"secret","spec":{"mem":4},"tags":["a"],"vCPU":2}`},
+		{name: "rename", ops: []config.MWConfig{transformOpConf("rename", nil, "spec.cpu", "vCPU")}, expected: `{"name":"vm","password":"secret","spec":{"vCPU":2,"mem":4},"tags":["a"]}`},
+		{name: "set", ops: []config.MWConfig{transformOpConf("set", "aws", "spec.provider")}, expected: `{"name":"vm","password":"secret","spec":{"cpu":2,"mem":4,"provider":"aws"},"tags":["a"]}`},
+		// 값이 없는 이전 Backend 응답 값은 제거
+		{name: "template", ops: []config.MWConfig{transformOpConf("template", "{{.Ns}}-{{.Resp0_id}}", "nsId")}, expected: `{"name":"vm","password":"secret","spec":{"cpu":2,"mem":4},"tags":["a"],"nsId":"ns-01-"}`},
+		// 처리는 설정된 순서대로 적용
+		{name: "chained", ops: []config.MWConfig{
+			transformOpConf("whitelist", nil, "name", "spec.cpu"),
+			transformOpConf("rename", nil, "spec.cpu", "vCPU"),
+			transformOpConf("set", true, "dryRun"),
+		}, expected: `{"name":"vm","spec":{"vCPU":2},"dryRun":true}`},
+	} {
+		rf := NewRequestFormatter(newTransformBackend(tc.ops...))
+		if rf == nil {
+			t.Fatalf("%s: request formatter should be created", tc.name)
+		}
+
+		req := newBodyRequest(body)
+		headers := req.Headers
+		if err := rf.Format(req); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err.Error())
+		}
+		raw, _ := ioutil.ReadAll(req.Body)
+		assertJSON(t, tc.name, json.RawMessage(raw), tc.expected)
+
+		// 변환된 Body 기준으로 Header 재 설정 (원본 Header는 유지)
+		if req.Headers["Content-Length"][0] != strconv.Itoa(len(raw)) || req.Headers["Content-Type"][0] != "application/json" {
+			t.Errorf("%s: unexpected headers: %v", tc.name, req.Headers)
+		}
+		if headers["Content-Length"][0] != strconv.Itoa(len(body)) {
+			t.Errorf("%s: original headers should not be changed: %v", tc.name, headers)
+		}
+	}
+}
+
+func TestRequestFormatter_body(t *testing.T) {
+	bConf := newTransformBackend(transformOpConf("del", nil, "password"))
+	bConf.MaxBodySize = 32
+	rf := NewRequestFormatter(bConf)
+
+	// Body가 없거나 비어있는 경우는 변환하지 않음
+	req := &Request{}
+	if err := rf.Format(req); err != nil || req.Body != nil {
+		t.Errorf("request without body should not be changed: %v", err)
+	}
+	req = newBodyRequest("  ")
+	if err := rf.Format(req); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if raw, _ := ioutil.ReadAll(req.Body); string(raw) != "  " {
+		t.Errorf("empty body should be kept: %q", raw)
+	}
+
+	for _, tc := range []struct {
+		body string
+		err  error
+	}{
+		{body: `["a"]`, err: errInvalidRequestBody},
+		{body: `name=vm`, err: errInvalidRequestBody},
+		{body: `{"name":"` + strings.Repeat("x", 32) + `"}`, err: errBodyTooLarge},
+	} {
+		if err := rf.Format(newBodyRequest(tc.body)); err != tc.err {
+			t.Errorf("%q: unexpected error: %v", tc.body, err)
+		}
+	}
+}
+
+func TestNewRequestFormatter_config(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		bConf *config.BackendConfig
+	}{
+		{name: "no middleware", bConf: &config.BackendConfig{}},
+		{name: "not a list", bConf: &config.BackendConfig{Middleware: newProxyMiddleware(config.MWConfig{requestTransformKey: "del"})}},
+		// 설정 검증을 거치지 않은 잘못된 처리는 무시
+		{name: "invalid ops", bConf: newTransformBackend(config.MWConfig{"args": []interface{}{"a"}}, transformOpConf("move", nil, "a"))},
+	} {
+		if rf := NewRequestFormatter(tc.bConf); rf != nil {
+			t.Errorf("%s: request formatter should not be created", tc.name)
+		}
+	}
+}
+
+func TestRequestBuilderChain_transform(t *testing.T) {
+	skipWithoutMapEncoding(t)
+	bConf := newTransformBackend(transformOpConf("whitelist", nil, "name"))
+
+	var received string
+	next := func(_ context.Context, req *Request) (*Response, error) {
+		raw, _ := ioutil.ReadAll(req.Body)
+		received = string(raw)
+		return &Response{IsComplete: true}, nil
+	}
+	p := NewRequestBuilderChain(bConf)(next)
+
+	if _, err := p(context.Background(), newBodyRequest(`{"name":"vm","password":"secret"}`)); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if received != `{"name":"vm"}` {
+		t.Errorf("transformed body should be sent: %q", received)
+	}
+
+	// 변환 오류는 Backend 호출 없이 반환
+	received = ""
+	if _, err := p(context.Background(), newBodyRequest(`[1]`)); err != errInvalidRequestBody || received != "" {
+		t.Errorf("invalid body should be rejected: %v, %q", err, received)
+	}
+}
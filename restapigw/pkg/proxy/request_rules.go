@@ -0,0 +1,171 @@
+// Package proxy - Backend 설정의 Header, Query String 조작 규칙 (rename, remove, set, add) 을 Request에 적용하는 패키지
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/jwt"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/observability"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	clientIPVariable  = "{client_ip}"
+	requestIDVariable = "{request_id}"
+
+	// JWT 토큰 추출 위치 기본 값
+	defaultTokenLookup = "header:Authorization"
+)
+
+var (
+	// JWT Claim 변수 ("{jwt.<claim>}") 식별용 정규식
+	reJWTClaim = regexp.MustCompile(`\{jwt\.([\w-]+)\}`)
+)
+
+// ===== [ Types ] =====
+
+// ClaimsVerifier - 클라이언트 JWT의 서명과 만기 시간을 검증하고 Claim 정보를 추출하는 구조
+type ClaimsVerifier struct {
+	parser *jwt.Parser
+}
+
+// ruleVariables - 조작 규칙 값에 적용할 Request 기반의 변수 정보 구조
+type ruleVariables struct {
+	ctx      context.Context
+	req      *Request
+	headers  map[string][]string
+	query    map[string][]string
+	verifier *ClaimsVerifier
+	claims   map[string]interface{}
+}
+
+// ===== [ Implementations ] =====
+
+// Claims - 지정한 Header, Query String에서 토큰을 추출하고 검증된 경우만 Claim 정보 반환 (토큰이 없거나 검증에 실패한 경우는 빈 맵)
+func (cv *ClaimsVerifier) Claims(headers, query map[string][]string) map[string]interface{} {
+	if cv == nil {
+		return map[string]interface{}{}
+	}
+	req := &http.Request{Header: http.Header(headers), URL: &url.URL{RawQuery: url.Values(query).Encode()}}
+	token, err := cv.parser.ParseFromRequest(req)
+	if err != nil || token == nil || !token.Valid {
+		return map[string]interface{}{}
+	}
+	if claims, ok := token.Claims.(*jwt.AppClaims); ok {
+		return claims.MapClaims
+	}
+	return map[string]interface{}{}
+}
+
+// resolve - 지정한 값에 존재하는 Endpoint 파라미터와 Request 기반 변수들을 실제 값으로 변경
+func (rv *ruleVariables) resolve(v string) string {
+	v = replaceParams(v, rv.req.Params, nil)
+	if !strings.Contains(v, "{") {
+		return v
+	}
+
+	if strings.Contains(v, clientIPVariable) {
+		clientIP := ""
+		if ips, ok := rv.req.Headers["X-Forwarded-For"]; ok && len(ips) > 0 {
+			clientIP = ips[0]
+		}
+		v = strings.Replace(v, clientIPVariable, clientIP, -1)
+	}
+	if strings.Contains(v, requestIDVariable) {
+		v = strings.Replace(v, requestIDVariable, observability.RequestIDFromContext(rv.ctx), -1)
+	}
+
+	return reJWTClaim.ReplaceAllStringFunc(v, func(m string) string {
+		if rv.claims == nil {
+			rv.claims = rv.verifier.Claims(rv.headers, rv.query)
+		}
+		if c, ok := rv.claims[reJWTClaim.FindStringSubmatch(m)[1]]; ok && c != nil {
+			return fmt.Sprintf("%v", c)
+		}
+		return ""
+	})
+}
+
+// ApplyRules - Backend 설정의 Header, Query String 조작 규칙을 Request에 적용 (rename, remove, set, add 순서)
+// (JWT Claim 변수는 지정한 ClaimsVerifier로 검증된 토큰의 Claim 정보만 사용하고, 조작 규칙 적용 전의 Header, Query String 에서 토큰 추출)
+func (r *Request) ApplyRules(ctx context.Context, bConf *config.BackendConfig, verifier *ClaimsVerifier) {
+	if bConf.HeaderRules == nil && bConf.QueryRules == nil {
+		return
+	}
+
+	rv := &ruleVariables{ctx: ctx, req: r, headers: r.Headers, query: r.Query, verifier: verifier}
+	if bConf.HeaderRules != nil {
+		r.Headers = applyRules(bConf.HeaderRules, CloneMapValues(r.Headers), http.CanonicalHeaderKey, rv)
+	}
+	if bConf.QueryRules != nil {
+		r.Query = applyRules(bConf.QueryRules, CloneMapValues(r.Query), func(k string) string { return k }, rv)
+	}
+}
+
+// ===== [ Private Functions ] =====
+
+// applyRules - 지정한 맵에 조작 규칙을 적용 (키는 지정한 함수로 정규화)
+func applyRules(rules *config.RequestRulesConfig, values map[string][]string, key func(string) string, rv *ruleVariables) map[string][]string {
+	for from, to := range rules.Rename {
+		if vs, ok := values[key(from)]; ok {
+			delete(values, key(from))
+			values[key(to)] = vs
+		}
+	}
+	for _, k := range rules.Remove {
+		delete(values, key(k))
+	}
+	for k, v := range rules.Set {
+		values[key(k)] = []string{rv.resolve(v)}
+	}
+	for k, v := range rules.Add {
+		values[key(k)] = append(values[key(k)], rv.resolve(v))
+	}
+	return values
+}
+
+// ruleTemplateValues - 지정한 Backend 설정의 Header, Query String 조작 규칙에 지정된 값 목록 반환
+func ruleTemplateValues(bConf *config.BackendConfig) []string {
+	values := []string{}
+	for _, rules := range []*config.RequestRulesConfig{bConf.HeaderRules, bConf.QueryRules} {
+		if rules == nil {
+			continue
+		}
+		for _, v := range rules.Set {
+			values = append(values, v)
+		}
+		for _, v := range rules.Add {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// ===== [ Public Functions ] =====
+
+// NewClaimsVerifier - 지정한 JWT 검증 설정으로 ClaimsVerifier 생성 (설정이 없는 경우는 nil, Claim 정보 사용 불가)
+func NewClaimsVerifier(conf *config.JWTConfig) *ClaimsVerifier {
+	if conf == nil {
+		return nil
+	}
+	methods := make([]jwt.SigningMethod, 0, len(conf.SigningMethods))
+	for _, sm := range conf.SigningMethods {
+		methods = append(methods, jwt.SigningMethod{Alg: sm.Alg, Key: sm.Key})
+	}
+	lookup := conf.TokenLookup
+	if lookup == "" {
+		lookup = defaultTokenLookup
+	}
+	return &ClaimsVerifier{parser: &jwt.Parser{Config: jwt.ParserConfig{
+		SigningMethods: methods,
+		TokenLookup:    lookup,
+		Leeway:         int64(conf.Leeway.Seconds()),
+	}}}
+}
@@ -0,0 +1,98 @@
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	jwtgo "github.com/dgrijalva/jwt-go"
+)
+
+const testJWTSecret = "test-secret"
+
+func signedToken(t *testing.T, method jwtgo.SigningMethod, key interface{}, claims jwtgo.MapClaims) string {
+	token, err := jwtgo.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("can not sign the token: %s", err.Error())
+	}
+	return token
+}
+
+func newRulesBackend(jwtConf *config.JWTConfig) *config.BackendConfig {
+	return &config.BackendConfig{
+		HeaderRules: &config.RequestRulesConfig{
+			Remove: []string{"Authorization"},
+			Set:    map[string]string{"X-User": "{jwt.sub}", "X-Tenant": "{jwt.tenant}"},
+		},
+		JWT: jwtConf,
+	}
+}
+
+func TestApplyRules_jwtClaims(t *testing.T) {
+	jwtConf := &config.JWTConfig{SigningMethods: []*config.JWTSigningMethodConfig{{Alg: "HS256", Key: testJWTSecret}}}
+	valid := signedToken(t, jwtgo.SigningMethodHS256, []byte(testJWTSecret), jwtgo.MapClaims{"sub": "alice", "tenant": "t1"})
+
+	// 검증된 토큰의 서명은 유지하고 Payload 만 변경한 토큰
+	parts := strings.Split(valid, ".")
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","tenant":"t0"}`))
+	tampered := strings.Join(parts, ".")
+
+	for _, tc := range []struct {
+		name   string
+		conf   *config.JWTConfig
+		token  string
+		user   string
+		tenant string
+	}{
+		{name: "verified token", conf: jwtConf, token: valid, user: "alice", tenant: "t1"},
+		{name: "without verifier", conf: nil, token: valid},
+		{name: "forged signature", conf: jwtConf, token: signedToken(t, jwtgo.SigningMethodHS256, []byte("other-secret"), jwtgo.MapClaims{"sub": "admin"})},
+		{name: "unsigned token", conf: jwtConf, token: signedToken(t, jwtgo.SigningMethodNone, jwtgo.UnsafeAllowNoneSignatureType, jwtgo.MapClaims{"sub": "admin"})},
+		{name: "tampered payload", conf: jwtConf, token: tampered},
+		{name: "expired token", conf: jwtConf, token: signedToken(t, jwtgo.SigningMethodHS256, []byte(testJWTSecret), jwtgo.MapClaims{"sub": "alice", "exp": time.Now().Add(-time.Minute).Unix()})},
+		{name: "without token", conf: jwtConf},
+	} {
+		bConf := newRulesBackend(tc.conf)
+		headers := map[string][]string{}
+		if tc.token != "" {
+			headers["Authorization"] = []string{"Bearer " + tc.token}
+		}
+		req := &Request{Headers: headers}
+		req.ApplyRules(context.Background(), bConf, NewClaimsVerifier(bConf.JWT))
+
+		if _, ok := req.Headers["Authorization"]; ok {
+			t.Errorf("%s: authorization header should be removed", tc.name)
+		}
+		if got := req.Headers["X-User"]; len(got) != 1 || got[0] != tc.user {
+			t.Errorf("%s: unexpected X-User: %v", tc.name, got)
+		}
+		if got := req.Headers["X-Tenant"]; len(got) != 1 || got[0] != tc.tenant {
+			t.Errorf("%s: unexpected X-Tenant: %v", tc.name, got)
+		}
+	}
+}
+
+func TestApplyRules_jwtTokenLookup(t *testing.T) {
+	jwtConf := &config.JWTConfig{
+		SigningMethods: []*config.JWTSigningMethodConfig{{Alg: "HS256", Key: testJWTSecret}},
+		TokenLookup:    "query:access_token",
+	}
+	token := signedToken(t, jwtgo.SigningMethodHS256, []byte(testJWTSecret), jwtgo.MapClaims{"sub": "alice"})
+
+	bConf := &config.BackendConfig{
+		QueryRules: &config.RequestRulesConfig{Remove: []string{"access_token"}, Set: map[string]string{"user": "{jwt.sub}"}},
+		JWT:        jwtConf,
+	}
+	req := &Request{Headers: map[string][]string{}, Query: map[string][]string{"access_token": {token}}}
+	req.ApplyRules(context.Background(), bConf, NewClaimsVerifier(bConf.JWT))
+
+	if _, ok := req.Query["access_token"]; ok {
+		t.Error("access_token should be removed")
+	}
+	if got := req.Query.Get("user"); got != "alice" {
+		t.Errorf("unexpected user: %s", got)
+	}
+}
@@ -0,0 +1,30 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestRequestClone_bufferedBody(t *testing.T) {
+	req := &Request{Body: newBufferedBody([]byte(`{"name":"vm"}`))}
+
+	// 보관된 Body는 복제본 마다 처음부터 다시 읽을 수 있음
+	for i, r := range []*Request{req, CloneRequest(req), CloneRequest(req)} {
+		c := r.Clone()
+		body, err := ioutil.ReadAll(c.Body)
+		if err != nil || string(body) != `{"name":"vm"}` {
+			t.Errorf("clone[%d]: unexpected body: %q, %v", i, body, err)
+		}
+	}
+	if err := req.Body.Close(); err != nil {
+		t.Errorf("buffered body should be closed without error: %s", err.Error())
+	}
+
+	// 보관되지 않은 Body는 그대로 공유
+	plain := ioutil.NopCloser(strings.NewReader("plain"))
+	req = &Request{Body: plain}
+	if c := req.Clone(); c.Body != plain {
+		t.Error("plain body should be shared")
+	}
+}
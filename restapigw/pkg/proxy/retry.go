@@ -0,0 +1,160 @@
+// Package proxy - Backend 호출 실패시 Backoff 대기 후 다른 Host로 재 시도하는 처리 패키지
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	coreRand "github.com/cloud-barista/cb-apigw/restapigw/pkg/core/rand"
+)
+
+// ===== [ Constants and Variables ] =====
+
+var (
+	// 재 시도해도 같은 결과가 보장되는 (멱등성) HTTP 메서드
+	idempotentMethods = map[string]bool{"GET": true, "HEAD": true, "OPTIONS": true, "TRACE": true, "PUT": true, "DELETE": true}
+)
+
+// ===== [ Types ] =====
+
+type (
+	// RetryReporter - 재 시도가 발생한 Backend 호출의 재 시도 횟수와 최종 성공 여부를 수집하는 함수 형식 (Metrics 연계용)
+	RetryReporter func(name string, retries int, succeeded bool)
+
+	// retryPolicy - Backend 별 재 시도 판단 및 대기 시간 산정 구조
+	retryPolicy struct {
+		conf        *config.RetryConfig
+		statusCodes map[int]bool
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// retryable - 호출 결과가 재 시도 대상 (재 시도 상태 코드 또는 Network 오류) 인지 검증 (Context가 종료된 경우는 재 시도 불가)
+func (rp *retryPolicy) retryable(ctx context.Context, res *Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		switch err.(type) {
+		case responseError, core.WrappedError:
+			return rp.statusCodes[errorStatusCode(err)]
+		}
+		return isNetworkError(err)
+	}
+	return res != nil && rp.statusCodes[res.Metadata.StatusCode]
+}
+
+// backoff - 지정한 재 시도 순서에 대한 대기 시간 산정 (지수 증가, 최대 값 제한, 절반 범위의 Jitter 적용)
+func (rp *retryPolicy) backoff(retry int) time.Duration {
+	d := rp.conf.Backoff
+	for i := 1; i < retry && (rp.conf.MaxBackoff <= 0 || d < rp.conf.MaxBackoff); i++ {
+		d *= 2
+	}
+	if rp.conf.MaxBackoff > 0 && d > rp.conf.MaxBackoff {
+		d = rp.conf.MaxBackoff
+	}
+	half := d / 2
+	return half + time.Duration(int64(half)*int64(coreRand.Uint32n(1000))/1000)
+}
+
+// ===== [ Private Functions ] =====
+
+// isNetworkError - 연결 실패, 연결 재 설정 등 Backend 와의 통신 과정에서 발생한 오류인지 검증
+func isNetworkError(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// discardResponse - 재 시도로 사용하지 않을 응답의 Stream을 정리
+func discardResponse(res *Response) {
+	if res == nil || res.Io == nil {
+		return
+	}
+	if c, ok := res.Io.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+// ===== [ Public Functions ] =====
+
+// NewRetryChain - Backend의 Retry 설정에 따라 실패한 호출을 Backoff 대기 후 재 시도하는 Proxy 호출 체인 생성
+// (Load Balancer 앞에 구성되므로 재 시도마다 Host를 다시 선택하며, Context 처리 기한 내에서만 재 시도)
+func NewRetryChain(bConf *config.BackendConfig, report RetryReporter) CallChain {
+	return func(next ...Proxy) Proxy {
+		if len(next) > 1 {
+			panic(ErrTooManyProxies)
+		}
+		if bConf.Retry == nil || bConf.Retry.MaxAttempts <= 1 {
+			return next[0]
+		}
+
+		rp := &retryPolicy{conf: bConf.Retry, statusCodes: make(map[int]bool, len(bConf.Retry.StatusCodes))}
+		for _, code := range bConf.Retry.StatusCodes {
+			rp.statusCodes[code] = true
+		}
+		name := bConf.URLPattern
+		maxSize := maxBodySize(bConf)
+
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if !rp.conf.AllowNonIdempotent && !idempotentMethods[strings.ToUpper(req.Method)] {
+				return next[0](ctx, req)
+			}
+
+			// 재 시도마다 Body를 다시 전송할 수 있도록 보관
+			ok, err := bufferRequestBody(req, maxSize)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				logger.Warnf("[API G/W] Proxy > Request body too large, skip retry for %s", name)
+				return next[0](ctx, req)
+			}
+
+			var res *Response
+			retries := 0
+			for {
+				res, err = next[0](ctx, req)
+				if retries+1 >= rp.conf.MaxAttempts || !rp.retryable(ctx, res, err) {
+					break
+				}
+
+				// 대기 후 재 시도할 시간이 남아있지 않은 경우는 현재 결과 반환
+				wait := rp.backoff(retries + 1)
+				if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= wait {
+					break
+				}
+
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+				case <-timer.C:
+				}
+				if ctx.Err() != nil {
+					break
+				}
+
+				discardResponse(res)
+				retries++
+				logger.Debugf("[CallChain] Retry > %s attempt %d/%d", name, retries+1, rp.conf.MaxAttempts)
+			}
+
+			if report != nil && retries > 0 {
+				report(name, retries, err == nil && (res == nil || !rp.statusCodes[res.Metadata.StatusCode]))
+			}
+			return res, err
+		}
+	}
+}
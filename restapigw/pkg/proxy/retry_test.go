@@ -0,0 +1,211 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/sd"
+)
+
+func newRetryPolicy(conf *config.RetryConfig) *retryPolicy {
+	rp := &retryPolicy{conf: conf, statusCodes: map[int]bool{}}
+	for _, code := range conf.StatusCodes {
+		rp.statusCodes[code] = true
+	}
+	return rp
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	rp := newRetryPolicy(&config.RetryConfig{Backoff: 100 * time.Millisecond, MaxBackoff: 350 * time.Millisecond})
+
+	// 재 시도마다 2배씩 증가하고 최대 값으로 제한, Jitter는 절반 범위 내에서 적용
+	for _, tc := range []struct {
+		retry int
+		max   time.Duration
+	}{
+		{retry: 1, max: 100 * time.Millisecond},
+		{retry: 2, max: 200 * time.Millisecond},
+		{retry: 3, max: 350 * time.Millisecond},
+		{retry: 10, max: 350 * time.Millisecond},
+	} {
+		for i := 0; i < 100; i++ {
+			if d := rp.backoff(tc.retry); d < tc.max/2 || d > tc.max {
+				t.Fatalf("retry %d: backoff %s out of range [%s, %s]", tc.retry, d, tc.max/2, tc.max)
+			}
+		}
+	}
+
+	// 최대 값이 없는 경우는 제한 없이 증가
+	rp = newRetryPolicy(&config.RetryConfig{Backoff: 100 * time.Millisecond})
+	if d := rp.backoff(5); d < 800*time.Millisecond || d > 1600*time.Millisecond {
+		t.Errorf("unlimited backoff out of range: %s", d)
+	}
+}
+
+func TestRetryPolicy_retryable(t *testing.T) {
+	rp := newRetryPolicy(&config.RetryConfig{StatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable}})
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, tc := range []struct {
+		name     string
+		ctx      context.Context
+		res      *Response
+		err      error
+		expected bool
+	}{
+		{name: "retry status", res: &Response{Metadata: Metadata{StatusCode: http.StatusServiceUnavailable}}, expected: true},
+		{name: "other status", res: &Response{Metadata: Metadata{StatusCode: http.StatusInternalServerError}}},
+		{name: "success", res: &Response{Metadata: Metadata{StatusCode: http.StatusOK}}},
+		{name: "retry status error", err: core.NewWrappedError(http.StatusBadGateway, "bad gateway", nil), expected: true},
+		{name: "client error", err: core.NewWrappedError(http.StatusNotFound, "not found", nil)},
+		{name: "network error", err: &net.OpError{Op: "dial", Err: errors.New("timeout")}, expected: true},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), expected: true},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), expected: true},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, expected: true},
+		{name: "other error", err: errors.New("invalid response")},
+		{name: "context canceled", ctx: canceled, res: &Response{Metadata: Metadata{StatusCode: http.StatusServiceUnavailable}}},
+	} {
+		ctx := tc.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if got := rp.retryable(ctx, tc.res, tc.err); got != tc.expected {
+			t.Errorf("%s: unexpected retryable %v", tc.name, got)
+		}
+	}
+}
+
+// countingProxy - 호출 횟수를 기록하고 지정한 횟수만큼 503 응답 후 정상 응답하는 Proxy
+func countingProxy(calls *int, failures int) Proxy {
+	return func(_ context.Context, _ *Request) (*Response, error) {
+		*calls++
+		if *calls <= failures {
+			return &Response{Metadata: Metadata{StatusCode: http.StatusServiceUnavailable}}, nil
+		}
+		return &Response{Metadata: Metadata{StatusCode: http.StatusOK}, IsComplete: true}, nil
+	}
+}
+
+func TestRetryChain_methods(t *testing.T) {
+	for _, tc := range []struct {
+		method        string
+		nonIdempotent bool
+		calls         int
+	}{
+		{method: http.MethodGet, calls: 3},
+		{method: http.MethodPut, calls: 3},
+		{method: http.MethodDelete, calls: 3},
+		{method: http.MethodPost, calls: 1},
+		{method: http.MethodPatch, calls: 1},
+		{method: http.MethodPost, nonIdempotent: true, calls: 3},
+	} {
+		bConf := &config.BackendConfig{URLPattern: "/retry", Retry: &config.RetryConfig{
+			MaxAttempts: 3, Backoff: time.Millisecond, StatusCodes: []int{http.StatusServiceUnavailable}, AllowNonIdempotent: tc.nonIdempotent,
+		}}
+		calls := 0
+		var retries int
+		var succeeded bool
+		report := func(_ string, r int, s bool) { retries, succeeded = r, s }
+
+		p := NewRetryChain(bConf, report)(countingProxy(&calls, 5))
+		res, err := p(context.Background(), &Request{Method: tc.method})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.method, err.Error())
+		}
+		if calls != tc.calls {
+			t.Errorf("%s (allow non idempotent: %v): unexpected calls %d", tc.method, tc.nonIdempotent, calls)
+		}
+		// 최대 호출 횟수까지 실패한 경우는 마지막 결과 반환
+		if res.Metadata.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("%s: last response should be returned: %d", tc.method, res.Metadata.StatusCode)
+		}
+		if retries != tc.calls-1 || succeeded {
+			t.Errorf("%s: unexpected report: %d, %v", tc.method, retries, succeeded)
+		}
+	}
+}
+
+func TestRetryChain_deadline(t *testing.T) {
+	bConf := &config.BackendConfig{URLPattern: "/retry", Retry: &config.RetryConfig{
+		MaxAttempts: 5, Backoff: time.Second, StatusCodes: []int{http.StatusServiceUnavailable},
+	}}
+	calls := 0
+	p := NewRetryChain(bConf, nil)(countingProxy(&calls, 5))
+
+	// 대기 시간이 남은 처리 시간보다 긴 경우는 재 시도하지 않음
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	res, err := p(ctx, &Request{Method: http.MethodGet})
+	if err != nil || res.Metadata.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("unexpected result: %+v, %v", res, err)
+	}
+	if calls != 1 || time.Since(begin) > 50*time.Millisecond {
+		t.Errorf("retry should be skipped without waiting, calls: %d, elapsed: %s", calls, time.Since(begin))
+	}
+}
+
+func TestRetryChain_bodyReplay(t *testing.T) {
+	bConf := &config.BackendConfig{
+		URLPattern: "/retry",
+		Method:     http.MethodPut,
+		Hosts:      []*config.HostConfig{{Host: "http://a"}, {Host: "http://b"}},
+		Timeout:    time.Second,
+		Retry:      &config.RetryConfig{MaxAttempts: 3, Backoff: time.Millisecond, StatusCodes: []int{http.StatusServiceUnavailable}},
+	}
+
+	var bodies []string
+	bf := func(_ *config.BackendConfig) Proxy {
+		return func(_ context.Context, req *Request) (*Response, error) {
+			b, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			bodies = append(bodies, string(b))
+			if len(bodies) < 3 {
+				return &Response{Metadata: Metadata{StatusCode: http.StatusServiceUnavailable}}, nil
+			}
+			return &Response{Metadata: Metadata{StatusCode: http.StatusOK}, IsComplete: true}, nil
+		}
+	}
+
+	var succeeded bool
+	pf := NewDefaultFactoryWithReporters(bf, *logging.NewLogger(), sd.GetSubscriber, Reporters{Retry: func(_ string, _ int, s bool) { succeeded = s }})
+	p := pf.(defaultFactory).newStack(bConf)
+	req := &Request{
+		Method:  http.MethodPut,
+		Params:  map[string]string{},
+		Headers: map[string][]string{},
+		Body:    ioutil.NopCloser(strings.NewReader(`{"name":"retry"}`)),
+	}
+	res, err := p(context.Background(), req)
+	if err != nil || res.Metadata.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected result: %+v, %v", res, err)
+	}
+
+	// 재 시도마다 동일한 Body 전송
+	if len(bodies) != 3 {
+		t.Fatalf("unexpected attempts: %d", len(bodies))
+	}
+	for i, b := range bodies {
+		if b != `{"name":"retry"}` {
+			t.Errorf("attempt %d: unexpected body: %q", i+1, b)
+		}
+	}
+	if !succeeded {
+		t.Error("retry should be reported as succeeded")
+	}
+}
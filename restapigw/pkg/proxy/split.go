@@ -0,0 +1,119 @@
+// Package proxy - Canary 배포 등을 위해 Backend 구성 (Variant) 간의 트래픽을 분할하는 처리 패키지
+package proxy
+
+import (
+	"context"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	coreRand "github.com/cloud-barista/cb-apigw/restapigw/pkg/core/rand"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// DefaultVariantName - 어떤 Variant에도 선택되지 않은 요청을 처리하는 Endpoint Backend 설정의 Variant 명
+	DefaultVariantName = "default"
+)
+
+// ===== [ Types ] =====
+
+type (
+	// VariantReporter - 트래픽 분할로 선택된 Variant 별 처리 결과를 수집하는 함수 형식 (Metrics 연계용)
+	VariantReporter func(endpoint, variant string, duration time.Duration, res *Response, err error)
+
+	// splitter - 설정된 분할 방식에 따라서 요청을 처리할 Variant를 선택하는 구조
+	splitter struct {
+		by       string
+		key      string
+		variants []*config.VariantConfig
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// keyValue - 분할 Key ("header:<name>", "cookie:<name>", "ip") 에 해당하는 요청 값 반환
+func (s *splitter) keyValue(req *Request) string {
+	switch {
+	case s.key == "ip":
+		if ips, ok := req.Headers["X-Forwarded-For"]; ok && len(ips) > 0 {
+			return ips[0]
+		}
+	case strings.HasPrefix(s.key, "header:"):
+		if vs, ok := req.Headers[http.CanonicalHeaderKey(strings.TrimPrefix(s.key, "header:"))]; ok && len(vs) > 0 {
+			return vs[0]
+		}
+	case strings.HasPrefix(s.key, "cookie:"):
+		r := &http.Request{Header: req.Headers}
+		if c, err := r.Cookie(strings.TrimPrefix(s.key, "cookie:")); err == nil {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// choose - 요청을 처리할 Variant 인덱스 반환 (선택되지 않은 경우는 -1)
+func (s *splitter) choose(req *Request) int {
+	var bucket uint32
+	switch s.by {
+	case "match":
+		val := s.keyValue(req)
+		if val == "" {
+			return -1
+		}
+		for i, v := range s.variants {
+			for _, mv := range v.Values {
+				if mv == val {
+					return i
+				}
+			}
+		}
+		return -1
+	case "hash":
+		// 동일한 Key 값은 항상 같은 Variant로 배정 (Key 값이 없는 경우는 비율 기준)
+		if val := s.keyValue(req); val != "" {
+			h := fnv.New32a()
+			h.Write([]byte(val))
+			bucket = h.Sum32() % 100
+		} else {
+			bucket = coreRand.Uint32n(100)
+		}
+	default:
+		bucket = coreRand.Uint32n(100)
+	}
+
+	cumulative := uint32(0)
+	for i, v := range s.variants {
+		cumulative += uint32(v.Weight)
+		if bucket < cumulative {
+			return i
+		}
+	}
+	return -1
+}
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// NewSplitProxy - Endpoint의 트래픽 분할 설정에 따라 요청을 Variant 별 Proxy (선택되지 않은 경우는 기본 Proxy) 로 전달하는 Proxy 생성
+func NewSplitProxy(eConf *config.EndpointConfig, defaultProxy Proxy, variants []Proxy, report VariantReporter) Proxy {
+	s := &splitter{by: eConf.Split.By, key: eConf.Split.Key, variants: eConf.Split.Variants}
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		p, name := defaultProxy, DefaultVariantName
+		if idx := s.choose(req); idx >= 0 {
+			p, name = variants[idx], s.variants[idx].Name
+		}
+		logger.Debugf("[CallChain] Split > %s variant > %s", eConf.Endpoint, name)
+
+		begin := time.Now()
+		res, err := p(ctx, req)
+		if report != nil {
+			report(eConf.Endpoint, name, time.Since(begin), res, err)
+		}
+		return res, err
+	}
+}
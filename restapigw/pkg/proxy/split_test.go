@@ -0,0 +1,177 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+)
+
+func newSplitter(by, key string, variants ...*config.VariantConfig) *splitter {
+	return &splitter{by: by, key: key, variants: variants}
+}
+
+// requestWith - 지정한 Header 들로 요청 구성
+func requestWith(headers map[string]string) *Request {
+	req := &Request{Headers: map[string][]string{}}
+	for k, v := range headers {
+		req.Headers[k] = []string{v}
+	}
+	return req
+}
+
+func TestSplitter_keyValue(t *testing.T) {
+	req := requestWith(map[string]string{"X-User": "u1", "Cookie": "session=s1; group=beta", "X-Forwarded-For": "10.0.0.1"})
+
+	for key, expected := range map[string]string{
+		"header:x-user":  "u1",
+		"cookie:group":   "beta",
+		"ip":             "10.0.0.1",
+		"header:missing": "",
+		"cookie:missing": "",
+		"unknown":        "",
+	} {
+		if got := newSplitter("match", key).keyValue(req); got != expected {
+			t.Errorf("%s: unexpected value: %s", key, got)
+		}
+	}
+}
+
+func TestSplitter_match(t *testing.T) {
+	s := newSplitter("match", "header:X-Group",
+		&config.VariantConfig{Name: "beta", Values: []string{"beta", "qa"}},
+		&config.VariantConfig{Name: "canary", Values: []string{"canary"}})
+
+	for _, tc := range []struct {
+		group    string
+		expected int
+	}{
+		{group: "beta", expected: 0},
+		{group: "qa", expected: 0},
+		{group: "canary", expected: 1},
+		// 일치하지 않거나 Key 값이 없는 경우는 기본 Variant
+		{group: "stable", expected: -1},
+		{group: "", expected: -1},
+	} {
+		req := requestWith(map[string]string{})
+		if tc.group != "" {
+			req = requestWith(map[string]string{"X-Group": tc.group})
+		}
+		if got := s.choose(req); got != tc.expected {
+			t.Errorf("%q: unexpected variant %d", tc.group, got)
+		}
+	}
+}
+
+func TestSplitter_hash(t *testing.T) {
+	s := newSplitter("hash", "header:X-User",
+		&config.VariantConfig{Name: "canary", Weight: 10},
+		&config.VariantConfig{Name: "beta", Weight: 30})
+
+	for _, user := range []string{"alice", "bob", "carol", "dave"} {
+		h := fnv.New32a()
+		h.Write([]byte(user))
+		bucket := h.Sum32() % 100
+
+		expected := -1
+		switch {
+		case bucket < 10:
+			expected = 0
+		case bucket < 40:
+			expected = 1
+		}
+
+		// 동일한 Key 값은 항상 같은 Variant로 배정
+		req := requestWith(map[string]string{"X-User": user})
+		for i := 0; i < 20; i++ {
+			if got := s.choose(req); got != expected {
+				t.Fatalf("%s (bucket %d): unexpected variant %d", user, bucket, got)
+			}
+		}
+	}
+
+	// 서로 다른 Key 값은 가중치 비율로 분배
+	counts := make([]int, 3)
+	for i := 0; i < 10000; i++ {
+		counts[s.choose(requestWith(map[string]string{"X-User": fmt.Sprintf("user-%d", i)}))+1]++
+	}
+	assertRatio(t, "hash", counts, []float64{0.6, 0.1, 0.3})
+}
+
+func TestSplitter_percentage(t *testing.T) {
+	s := newSplitter("percentage", "",
+		&config.VariantConfig{Name: "canary", Weight: 20},
+		&config.VariantConfig{Name: "beta", Weight: 30})
+
+	counts := make([]int, 3)
+	for i := 0; i < 10000; i++ {
+		counts[s.choose(requestWith(map[string]string{}))+1]++
+	}
+	// 가중치 합계 외의 나머지는 기본 Variant
+	assertRatio(t, "percentage", counts, []float64{0.5, 0.2, 0.3})
+}
+
+// assertRatio - 기본 Variant (-1) 부터의 선택 횟수가 지정한 비율과 근사한지 검증
+func assertRatio(t *testing.T, name string, counts []int, ratios []float64) {
+	t.Helper()
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	for i, r := range ratios {
+		if got := float64(counts[i]) / float64(total); math.Abs(got-r) > 0.03 {
+			t.Errorf("%s: variant %d ratio %.3f, expected %.2f", name, i-1, got, r)
+		}
+	}
+}
+
+func TestNewSplitProxy(t *testing.T) {
+	eConf := &config.EndpointConfig{
+		Endpoint: "/split",
+		Split: &config.SplitConfig{By: "match", Key: "header:X-Group", Variants: []*config.VariantConfig{
+			{Name: "beta", Values: []string{"beta"}},
+		}},
+	}
+	errBeta := errors.New("beta failed")
+	defaultProxy := func(_ context.Context, _ *Request) (*Response, error) {
+		return &Response{Data: map[string]interface{}{"variant": "default"}}, nil
+	}
+	beta := func(_ context.Context, _ *Request) (*Response, error) {
+		time.Sleep(time.Millisecond)
+		return nil, errBeta
+	}
+
+	type report struct {
+		endpoint, variant string
+		duration          time.Duration
+		res               *Response
+		err               error
+	}
+	var reports []report
+	p := NewSplitProxy(eConf, defaultProxy, []Proxy{beta}, func(endpoint, variant string, d time.Duration, res *Response, err error) {
+		reports = append(reports, report{endpoint, variant, d, res, err})
+	})
+
+	if res, err := p(context.Background(), requestWith(map[string]string{"X-Group": "stable"})); err != nil || res.Data["variant"] != "default" {
+		t.Errorf("default proxy should be used: %+v, %v", res, err)
+	}
+	if _, err := p(context.Background(), requestWith(map[string]string{"X-Group": "beta"})); err != errBeta {
+		t.Errorf("variant proxy should be used: %v", err)
+	}
+
+	// Variant 별 처리 결과 전달
+	if len(reports) != 2 {
+		t.Fatalf("unexpected reports: %+v", reports)
+	}
+	if r := reports[0]; r.endpoint != "/split" || r.variant != DefaultVariantName || r.res == nil || r.err != nil {
+		t.Errorf("unexpected default report: %+v", r)
+	}
+	if r := reports[1]; r.variant != "beta" || r.err != errBeta || r.duration < time.Millisecond {
+		t.Errorf("unexpected variant report: %+v", r)
+	}
+}
@@ -0,0 +1,75 @@
+// Package render - 응답 결과를 Template 파일로 출력하기 위한 text/template 구성 패키지
+package render
+
+import (
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ===== [ Constants and Variables ] =====
+
+var (
+	// Template 에서 사용할 수 있는 기본 함수들
+	templateFuncs = template.FuncMap{
+		"json": toJSON,
+		"csv":  toCSV,
+		"join": join,
+	}
+)
+
+// ===== [ Types ] =====
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+
+// toJSON - 지정한 값을 JSON 문자열로 변환
+func toJSON(v interface{}) (string, error) {
+	b, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(v)
+	return string(b), err
+}
+
+// toCSV - 지정한 값들을 CSV 한 행으로 변환 (필요한 경우 Quote 처리, 줄바꿈 미 포함)
+func toCSV(values ...interface{}) (string, error) {
+	record := make([]string, len(values))
+	for i, v := range values {
+		if v != nil {
+			record[i] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	buf := &strings.Builder{}
+	w := csv.NewWriter(buf)
+	if err := w.Write(record); err != nil {
+		return "", err
+	}
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), w.Error()
+}
+
+// join - 지정한 배열의 항목들을 구분자로 연결한 문자열로 변환 (값이 없는 경우는 빈 문자열)
+func join(sep string, v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(t, sep)
+	case []interface{}:
+		parts := make([]string, len(t))
+		for i, e := range t {
+			parts[i] = fmt.Sprintf("%v", e)
+		}
+		return strings.Join(parts, sep)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// ===== [ Public Functions ] =====
+
+// NewTemplate - 지정한 파일을 기본 함수 (json, csv, join) 를 사용하는 text/template 으로 구성
+func NewTemplate(file string) (*template.Template, error) {
+	return template.New(filepath.Base(file)).Funcs(templateFuncs).ParseFiles(file)
+}
@@ -2,13 +2,18 @@ package gin
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"net/textproto"
 	"strings"
+	"time"
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/observability"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/router"
 	"github.com/gin-gonic/gin"
@@ -16,7 +21,10 @@ import (
 
 // ===== [ Constants and Variables ] =====
 
-const ()
+const (
+	// Request ID 전달에 사용할 Header 명
+	requestIDHeaderName = "X-Request-Id"
+)
 
 var (
 	logger = logging.NewLogger()
@@ -30,6 +38,12 @@ type responseError interface {
 	StatusCode() int
 }
 
+// backendErrors - Merging 과정에서 발생한 Backend 별 오류 정보를 제공하는 오류
+type backendErrors interface {
+	error
+	BackendErrors() []proxy.BackendError
+}
+
 // HandlerFactory - 지정된 Endpoint 설정과 Proxy 를 기반으로 동작하는 Gin Framework handler 팩토리 정의
 type HandlerFactory func(*config.EndpointConfig, proxy.Proxy) gin.HandlerFunc
 
@@ -37,6 +51,78 @@ type HandlerFactory func(*config.EndpointConfig, proxy.Proxy) gin.HandlerFunc
 
 // ===== [ Private Functions ] =====
 
+// getRequestID - Request Header ("X-Request-Id") 에 지정된 Request ID 반환 (없는 경우는 새로 생성)
+func getRequestID(c *gin.Context) string {
+	if id := c.GetHeader(requestIDHeaderName); id != "" {
+		return id
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// resultStatusCode - Proxy 처리 결과 (Response, 오류) 에 해당하는 상태 코드 반환 (정상 처리인 경우는 0)
+func resultStatusCode(response *proxy.Response, err error, errF router.ToHTTPError) int {
+	if response != nil && response.Metadata.StatusCode != 0 {
+		return response.Metadata.StatusCode
+	}
+	if err == nil {
+		return 0
+	}
+	if t, ok := err.(responseError); ok {
+		return t.StatusCode()
+	} else if e, ok := err.(core.WrappedError); ok {
+		return e.Code()
+	}
+	return errF(err)
+}
+
+// applyStatusMapping - 처리 결과 상태 코드에 해당하는 변환 규칙을 적용한 Response, 상태 코드, 오류 반환 (해당 규칙이 없는 경우는 변경 없이 상태 코드 0 반환)
+func applyStatusMapping(rules []*config.StatusMappingConfig, response *proxy.Response, err error, errF router.ToHTTPError) (*proxy.Response, int, error) {
+	status := resultStatusCode(response, err, errF)
+	if status == 0 {
+		return response, 0, err
+	}
+
+	var rule *config.StatusMappingConfig
+	for _, sm := range rules {
+		if sm.From == status {
+			rule = sm
+			break
+		}
+	}
+	if rule == nil {
+		return response, 0, err
+	}
+
+	if rule.Body == nil && response == nil {
+		if rule.To < http.StatusBadRequest {
+			return &proxy.Response{Data: map[string]interface{}{}, IsComplete: true, Metadata: proxy.Metadata{StatusCode: rule.To}}, rule.To, nil
+		}
+		return nil, rule.To, core.NewWrappedError(rule.To, err.Error(), err)
+	}
+
+	if response == nil {
+		response = &proxy.Response{}
+	}
+	switch body := proxy.CloneData(rule.Body).(type) {
+	case map[string]interface{}:
+		response.Data = body
+	case []interface{}:
+		// 배열은 Array 상태로 반환
+		response.Data = map[string]interface{}{core.CollectionTag: body, core.WrappingTag: core.CollectionTag}
+	}
+	response.Metadata.StatusCode = rule.To
+
+	if rule.To < http.StatusBadRequest {
+		response.IsComplete = true
+		return response, rule.To, nil
+	}
+	return response, rule.To, err
+}
+
 // ===== [ Public Functions ] =====
 
 // EndpointHandler - 지정된 Endpoint 설정과 Proxy를 연계 호출하는 Gin Framework handler 생성
@@ -122,11 +208,25 @@ func CustomErrorEndpointHandler(eConf *config.EndpointConfig, proxy proxy.Proxy,
 	isCacheEnabled := eConf.CacheTTL.Seconds() != 0
 	requestGenerator := NewRequest(eConf)
 	render := getRender(eConf)
+	upgradable := isUpgradable(eConf)
 
 	return func(c *gin.Context) {
-		requestCtx, cancel := context.WithTimeout(c, eConf.Timeout)
+		var requestCtx context.Context
+		var cancel context.CancelFunc
+		var headerTimer *time.Timer
+		isUpgrade := upgradable && isUpgradeRequest(c.Request)
+		if eConf.Streaming != nil || isUpgrade {
+			// Streaming, Upgrade는 클라이언트 연결 종료를 Backend로 전파하고, Endpoint Timeout은 Response Header 수신까지만 적용
+			requestCtx, cancel = newStreamContext(c, eConf)
+			headerTimer = time.AfterFunc(eConf.Timeout, cancel)
+		} else {
+			requestCtx, cancel = context.WithTimeout(observability.RequestIDToContext(c, getRequestID(c)), eConf.Timeout)
+		}
 		c.Header(core.AppHeaderName, fmt.Sprintf("Version %s", core.AppVersion))
 		response, err := proxy(requestCtx, requestGenerator(c, eConf.ExceptQueryStrings))
+		if headerTimer != nil {
+			headerTimer.Stop()
+		}
 		select {
 		case <-requestCtx.Done():
 			if err == nil {
@@ -135,6 +235,23 @@ func CustomErrorEndpointHandler(eConf *config.EndpointConfig, proxy proxy.Proxy,
 		default:
 		}
 
+		// Protocol 전환 (WebSocket 등) 이 수락된 경우는 연결 중계
+		if backend, ok := upgradedConn(response); ok {
+			if isUpgrade && err == nil {
+				serveUpgrade(c, eConf, response, backend)
+				cancel()
+				return
+			}
+			backend.Close()
+			response, err = nil, router.ErrInternalError
+		}
+
+		// 상태 코드 변환 규칙 적용
+		status := 0
+		if len(eConf.StatusMapping) > 0 {
+			response, status, err = applyStatusMapping(eConf.StatusMapping, response, err, errF)
+		}
+
 		complete := router.HeaderIncompleteResponseValue
 		if response != nil && len(response.Data) > 0 {
 			if response.IsComplete {
@@ -155,6 +272,9 @@ func CustomErrorEndpointHandler(eConf *config.EndpointConfig, proxy proxy.Proxy,
 		if err != nil {
 			// Proxy 처리 중에 발생한 오류들을 Header로 설정
 			c.Header(router.MessageResponseHeaderName, err.Error())
+			if be, ok := err.(backendErrors); ok {
+				c.Header(router.ErrorsResponseHeaderName, core.ToJSON(be.BackendErrors()))
+			}
 			logger.Errorf("[API G/W] Router > Endpoint Error Processing: %s", err.Error())
 
 			c.Error(err)
@@ -182,8 +302,18 @@ func CustomErrorEndpointHandler(eConf *config.EndpointConfig, proxy proxy.Proxy,
 			}
 		}
 
+		if status != 0 && err == nil {
+			c.Status(status)
+		}
+
 		// Response를 클라이언트로 출력
-		render(c, response)
+		if eConf.Streaming != nil {
+			stop := withIdleTimeout(eConf, response, cancel)
+			render(c, response)
+			stop()
+		} else {
+			render(c, response)
+		}
 		cancel()
 	}
 }
@@ -0,0 +1,59 @@
+package gin
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/router"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/transport/http/client"
+)
+
+func TestApplyStatusMapping(t *testing.T) {
+	rules := []*config.StatusMappingConfig{
+		{From: http.StatusNotFound, To: http.StatusOK, Body: map[string]interface{}{"items": []interface{}{}}},
+		{From: http.StatusInternalServerError, To: http.StatusBadGateway},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		keepCode bool
+		code     int
+		status   int
+		failed   bool
+		data     map[string]interface{}
+	}{
+		{name: "404 to 200 with body", keepCode: true, code: http.StatusNotFound, status: http.StatusOK, data: map[string]interface{}{"items": []interface{}{}}},
+		{name: "500 to 502", keepCode: true, code: http.StatusInternalServerError, status: http.StatusBadGateway, failed: true},
+		{name: "unmapped 403", keepCode: true, code: http.StatusForbidden, failed: true},
+		// 상태 코드를 유지하지 않으면 Body가 없는 오류는 502 로 처리
+		{name: "not kept 404", code: http.StatusNotFound, status: http.StatusBadGateway, failed: true},
+	} {
+		bConf := &config.BackendConfig{KeepStatusCode: tc.keepCode}
+		resp := &http.Response{StatusCode: tc.code, Body: ioutil.NopCloser(strings.NewReader(""))}
+		_, err := client.GetHTTPStatusHandler(bConf)(context.Background(), resp)
+
+		res, status, err := applyStatusMapping(rules, nil, err, router.DefaultToHTTPError)
+		if status != tc.status {
+			t.Errorf("%s: unexpected status %d", tc.name, status)
+		}
+		if (err != nil) != tc.failed {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if tc.data != nil {
+			if res == nil || !res.IsComplete || !reflect.DeepEqual(res.Data, tc.data) {
+				t.Errorf("%s: unexpected response: %+v", tc.name, res)
+			}
+		}
+		if tc.status >= http.StatusBadRequest {
+			if we, ok := err.(core.WrappedError); !ok || we.Code() != tc.status {
+				t.Errorf("%s: unexpected error status: %v", tc.name, err)
+			}
+		}
+	}
+}
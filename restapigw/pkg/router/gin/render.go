@@ -1,14 +1,19 @@
 package gin
 
 import (
+	"bytes"
+	"errors"
 	"io"
 	"net/http"
+	"net/textproto"
+	"net/url"
 	"sync"
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/encoding"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/render"
 	"github.com/gin-gonic/gin"
 )
 
@@ -17,6 +22,9 @@ import (
 const (
 	// NEGOTIATE - Backend의 Response 포맷과 Endpoint의 "OutputEncoding" 협상을 위한 식별자
 	NEGOTIATE = "nogotiate"
+
+	// Template Render의 기본 Content-Type
+	defaultTemplateContentType = "text/plain; charset=utf-8"
 )
 
 var (
@@ -35,6 +43,20 @@ var (
 // Render - Proxy 수행의 결과인 Response에 대한 Encoding과 Rendering 함수 정의
 type Render func(*gin.Context, *proxy.Response)
 
+// templateData - Template Render에 전달되는 데이터 구조
+type templateData struct {
+	// Merging 된 Response 데이터
+	Data map[string]interface{}
+	// 모든 Backend 응답이 정상 처리되었는지 여부
+	IsComplete bool
+	// Endpoint URL 파라미터
+	Params map[string]string
+	// Template 설정의 "headers_to_pass" 에 지정된 Request Header
+	Headers http.Header
+	// Request Query String
+	Query url.Values
+}
+
 // ===== [ Implementations ] =====
 
 // ===== [ Private Functions ] =====
@@ -143,6 +165,77 @@ func noopRender(c *gin.Context, res *proxy.Response) {
 	io.Copy(c.Writer, res.Io)
 }
 
+// checkTemplate - Endpoint 설정의 Output Encoding이 Template 인 경우 Template 파일 검증
+func checkTemplate(eConf *config.EndpointConfig) error {
+	if eConf.OutputEncoding != encoding.TEMPLATE || eConf.Streaming != nil {
+		return nil
+	}
+	if eConf.Template == nil {
+		return errors.New("no template defined")
+	}
+	_, err := render.NewTemplate(eConf.Template.File)
+	return err
+}
+
+// filterHeaders - 지정한 Header 목록 (Canonical) 에 해당하는 Request Header만 복사해서 반환
+func filterHeaders(headers http.Header, headersToPass []string) http.Header {
+	filtered := make(http.Header, len(headersToPass))
+	for _, k := range headersToPass {
+		if vs, ok := headers[k]; ok {
+			filtered[k] = append([]string{}, vs...)
+		}
+	}
+	return filtered
+}
+
+// newTemplateRender - Endpoint 설정에 지정된 Template 파일로 응답을 출력하는 Render 생성 (Template 오류인 경우는 JSON Render 사용)
+func newTemplateRender(eConf *config.EndpointConfig) Render {
+	if eConf.Template == nil {
+		logger.Errorf("[API G/W] Router > No template defined on %s, using json render", eConf.Endpoint)
+		return jsonRender
+	}
+	tmpl, err := render.NewTemplate(eConf.Template.File)
+	if err != nil {
+		logger.Errorf("[API G/W] Router > Invalid template on %s, using json render: %s", eConf.Endpoint, err.Error())
+		return jsonRender
+	}
+	contentType := eConf.Template.ContentType
+	if contentType == "" {
+		contentType = defaultTemplateContentType
+	}
+	headersToPass := make([]string, len(eConf.Template.HeadersToPass))
+	for i, h := range eConf.Template.HeadersToPass {
+		headersToPass[i] = textproto.CanonicalMIMEHeaderKey(h)
+	}
+
+	return func(c *gin.Context, res *proxy.Response) {
+		td := templateData{
+			Data:    map[string]interface{}{},
+			Params:  make(map[string]string, len(c.Params)),
+			Headers: filterHeaders(c.Request.Header, headersToPass),
+			Query:   c.Request.URL.Query(),
+		}
+		for _, param := range c.Params {
+			td.Params[param.Key] = param.Value
+		}
+		if res != nil {
+			delete(res.Data, core.WrappingTag)
+			if res.Data != nil {
+				td.Data = res.Data
+			}
+			td.IsComplete = res.IsComplete
+		}
+
+		buf := new(bytes.Buffer)
+		if err := tmpl.Execute(buf, td); err != nil {
+			logger.Errorf("[API G/W] Router > Failed to render template on %s: %s", eConf.Endpoint, err.Error())
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Data(c.Writer.Status(), contentType, buf.Bytes())
+	}
+}
+
 // getRender - Endpoint 설정에 지정된 Backend의 "encoding" 을 기준 Encoding(fallback)을 설정하고 Endpoint 설정의 "output_encoding" 기준으로 운영되는 Render 반환
 func getRender(eConf *config.EndpointConfig) Render {
 	fallback := jsonRender
@@ -153,6 +246,12 @@ func getRender(eConf *config.EndpointConfig) Render {
 	if eConf.OutputEncoding == "" {
 		return fallback
 	}
+	if eConf.Streaming != nil {
+		return streamRender
+	}
+	if eConf.OutputEncoding == encoding.TEMPLATE {
+		return newTemplateRender(eConf)
+	}
 
 	return getWithFallback(eConf.OutputEncoding, fallback)
 }
@@ -0,0 +1,138 @@
+package gin
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/encoding"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+	"github.com/gin-gonic/gin"
+)
+
+// writeTemplate - 임시 디렉터리에 지정한 내용의 Template 파일을 생성하고 경로 반환
+func writeTemplate(t *testing.T, content string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "template")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	file := filepath.Join(dir, "test.tmpl")
+	if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return file
+}
+
+func newTemplateEndpoint(tConf *config.TemplateConfig) *config.EndpointConfig {
+	return &config.EndpointConfig{Endpoint: "/template/:id", OutputEncoding: encoding.TEMPLATE, Template: tConf}
+}
+
+// renderTemplate - 지정한 Endpoint 설정의 Render로 Request와 Response를 처리한 결과 반환
+func renderTemplate(eConf *config.EndpointConfig, req *http.Request, res *proxy.Response) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "7"}}
+	getRender(eConf)(c, res)
+	// Gin Handler 처리 종료시와 같이 Body 없이 지정된 상태 코드 반영
+	c.Writer.WriteHeaderNow()
+	return w
+}
+
+func TestTemplateRender(t *testing.T) {
+	file := writeTemplate(t, `{{.Params.id}}|{{.Query.Get "page"}}|{{.IsComplete}}
+{{range .Data.collection}}{{csv .id .name}}
+{{end}}{{join "," .Data.tags}}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/template/7?page=2", nil)
+	res := &proxy.Response{Data: map[string]interface{}{
+		core.CollectionTag: []interface{}{map[string]interface{}{"id": 1, "name": "a,b"}},
+		core.WrappingTag:   core.CollectionTag,
+		"tags":             []interface{}{"x", "y"},
+	}, IsComplete: true}
+
+	w := renderTemplate(newTemplateEndpoint(&config.TemplateConfig{File: file, ContentType: "text/csv"}), req, res)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/csv" {
+		t.Errorf("unexpected response: %d, %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	if expected := "7|2|true\n1,\"a,b\"\nx,y"; w.Body.String() != expected {
+		t.Errorf("unexpected body: %q", w.Body.String())
+	}
+
+	// 응답이 없는 경우는 빈 데이터와 기본 Content-Type 사용
+	w = renderTemplate(newTemplateEndpoint(&config.TemplateConfig{File: file}), req, nil)
+	if w.Header().Get("Content-Type") != defaultTemplateContentType || w.Body.String() != "7|2|false\n" {
+		t.Errorf("unexpected empty response: %s, %q", w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
+
+func TestTemplateRender_headers(t *testing.T) {
+	file := writeTemplate(t, `{{range $k, $v := .Headers}}{{$k}}={{index $v 0}};{{end}}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/template/7", nil)
+	req.Header.Set("Accept-Language", "ko")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+
+	// 지정한 Header만 전달
+	w := renderTemplate(newTemplateEndpoint(&config.TemplateConfig{File: file, HeadersToPass: []string{"accept-language", "X-Missing"}}), req, nil)
+	if w.Body.String() != "Accept-Language=ko;" {
+		t.Errorf("only allowed headers should be exposed: %q", w.Body.String())
+	}
+
+	// 지정하지 않은 경우는 Header 미 전달
+	w = renderTemplate(newTemplateEndpoint(&config.TemplateConfig{File: file}), req, nil)
+	if w.Body.String() != "" {
+		t.Errorf("headers should not be exposed by default: %q", w.Body.String())
+	}
+}
+
+func TestTemplateRender_errors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/template/7", nil)
+	res := &proxy.Response{Data: map[string]interface{}{"a": 1}, IsComplete: true}
+
+	// 실행 오류는 500 처리
+	file := writeTemplate(t, `{{index .Data.a 1}}`)
+	if w := renderTemplate(newTemplateEndpoint(&config.TemplateConfig{File: file}), req, res); w.Code != http.StatusInternalServerError || w.Body.Len() != 0 {
+		t.Errorf("execution error should be 500: %d, %q", w.Code, w.Body.String())
+	}
+
+	// Template이 없거나 잘못된 경우는 JSON Render 사용
+	for _, tConf := range []*config.TemplateConfig{nil, {File: "./not-exists.tmpl"}} {
+		w := renderTemplate(newTemplateEndpoint(tConf), req, res)
+		if w.Body.String() != `{"a":1}` {
+			t.Errorf("%+v: json render should be used: %q", tConf, w.Body.String())
+		}
+	}
+}
+
+func TestCheckTemplate(t *testing.T) {
+	valid := writeTemplate(t, `{{.Data}}`)
+	invalid := writeTemplate(t, `{{.Data`)
+
+	for _, tc := range []struct {
+		name   string
+		eConf  *config.EndpointConfig
+		failed bool
+	}{
+		{name: "valid", eConf: newTemplateEndpoint(&config.TemplateConfig{File: valid})},
+		{name: "no template", eConf: newTemplateEndpoint(nil), failed: true},
+		{name: "not exists", eConf: newTemplateEndpoint(&config.TemplateConfig{File: valid + ".none"}), failed: true},
+		{name: "parse error", eConf: newTemplateEndpoint(&config.TemplateConfig{File: invalid}), failed: true},
+		// Template 인코딩이 아니거나 Streaming 인 경우는 검증하지 않음
+		{name: "json", eConf: &config.EndpointConfig{OutputEncoding: encoding.JSON}},
+		{name: "streaming", eConf: &config.EndpointConfig{OutputEncoding: encoding.TEMPLATE, Streaming: &config.StreamingConfig{}}},
+	} {
+		if err := checkTemplate(tc.eConf); (err != nil) != tc.failed {
+			t.Errorf("%s: unexpected result: %v", tc.name, err)
+		}
+	}
+}
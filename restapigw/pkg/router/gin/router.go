@@ -95,10 +95,10 @@ func (pc PipeConfig) registerAPIGroup(path string, handler gin.HandlerFunc, totB
 }
 
 // registerAPI - 지정한 정보를 기준으로 Gin Engine에 Endpoint Handler 등록
-func (pc PipeConfig) registerAPI(method, path string, handler gin.HandlerFunc, totBackends int) {
+func (pc PipeConfig) registerAPI(method, path string, handler gin.HandlerFunc, totBackends int, replicable bool) {
 	method = strings.ToTitle(method)
-	if method != http.MethodGet && totBackends > 1 {
-		pc.logger.Errorf("[API G/W] Router > Method: %s, endpoints must have a single backend! Ignoring -> %s", method, path)
+	if method != http.MethodGet && totBackends > 1 && !replicable {
+		pc.logger.Errorf("[API G/W] Router > Method: %s, endpoints must have a single backend (or enable mw-proxy.replicate_body)! Ignoring -> %s", method, path)
 		return
 	}
 
@@ -138,6 +138,12 @@ func (pc *PipeConfig) RegisterAPIs(sConf *config.ServiceConfig, defs []*config.E
 	for _, def := range defs {
 		// 활성화된 경우만 적용
 		if def.Active {
+			// Template 출력은 Template 파일이 정상인 경우만 등록
+			if err := checkTemplate(def); err != nil {
+				pc.logger.WithError(err).Errorf("[API G/W] Router > Invalid template. Skip to registering: %s", def.Name)
+				continue
+			}
+
 			// Endpoint에 연결되어 동작할 수 있도록 ProxyFactory의 Call chain에 대한 인스턴스 생성 (ProxyStack)
 			proxyStack, err := pc.proxyFactory.New(def)
 			if err != nil {
@@ -150,7 +156,7 @@ func (pc *PipeConfig) RegisterAPIs(sConf *config.ServiceConfig, defs []*config.E
 				pc.registerAPIGroup(def.Endpoint, pc.handlerFactory(def, proxyStack), len(def.Backend))
 			} else {
 				// Normal case
-				pc.registerAPI(def.Method, def.Endpoint, pc.handlerFactory(def, proxyStack), len(def.Backend))
+				pc.registerAPI(def.Method, def.Endpoint, pc.handlerFactory(def, proxyStack), len(def.Backend), proxy.IsBodyReplicationEnabled(def))
 			}
 		} else {
 			pc.logger.Infof("[API G/W] Router > Not actived. Skip to registering: %s", def.Name)
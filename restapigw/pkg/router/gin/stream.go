@@ -0,0 +1,97 @@
+package gin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/observability"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+	"github.com/gin-gonic/gin"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// Streaming 전달에 사용할 버퍼 크기
+	streamBufferSize = 32 * 1024
+)
+
+// ===== [ Types ] =====
+
+// idleTimeoutReader - 지정한 시간 동안 데이터 수신이 없으면 취소 함수를 호출하는 io.Reader 구조
+type idleTimeoutReader struct {
+	r       io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+// ===== [ Implementations ] =====
+
+// Read - 데이터를 읽고 Idle Timeout을 재 설정
+func (ir *idleTimeoutReader) Read(p []byte) (int, error) {
+	n, err := ir.r.Read(p)
+	ir.timer.Reset(ir.timeout)
+	return n, err
+}
+
+// ===== [ Private Functions ] =====
+
+// newStreamContext - Streaming Endpoint와 Upgrade 요청 처리에 사용할 Context 생성
+// (클라이언트 연결 종료시 취소되는 Request Context 기반, Endpoint Timeout은 Response Header 수신까지만 적용하고 이후는 Streaming 최대 유지 시간 적용)
+func newStreamContext(c *gin.Context, eConf *config.EndpointConfig) (context.Context, context.CancelFunc) {
+	ctx := observability.RequestIDToContext(c.Request.Context(), getRequestID(c))
+	if eConf.Streaming != nil && eConf.Streaming.MaxDuration > 0 {
+		return context.WithTimeout(ctx, eConf.Streaming.MaxDuration)
+	}
+	return context.WithCancel(ctx)
+}
+
+// withIdleTimeout - Streaming 설정에 Idle Timeout이 지정된 경우 Response의 io.Reader를 데이터 수신이 없으면 취소되도록 구성
+// (취소된 Context에 의해서 Backend Response Body가 닫히고 Streaming 종료)
+func withIdleTimeout(eConf *config.EndpointConfig, res *proxy.Response, cancel context.CancelFunc) func() {
+	if res == nil || res.Io == nil || eConf.Streaming.IdleTimeout <= 0 {
+		return func() {}
+	}
+	ir := &idleTimeoutReader{r: res.Io, timer: time.AfterFunc(eConf.Streaming.IdleTimeout, cancel), timeout: eConf.Streaming.IdleTimeout}
+	res.Io = ir
+	return func() { ir.timer.Stop() }
+}
+
+// streamRender - 아무 변환 없이 Backend 응답을 수신되는 대로 클라이언트로 전달 (Flush) 하는 Render 처리
+func streamRender(c *gin.Context, res *proxy.Response) {
+	if res == nil || res.Io == nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Status(res.Metadata.StatusCode)
+	for k, vs := range res.Metadata.Headers {
+		for _, v := range vs {
+			c.Writer.Header().Add(k, v)
+		}
+	}
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, err := res.Io.Read(buf)
+		if n > 0 {
+			if _, werr := c.Writer.Write(buf[:n]); werr != nil {
+				logger.Debugf("[API G/W] Router > Stream closed by client: %s", werr.Error())
+				return
+			}
+			c.Writer.Flush()
+		}
+		if err != nil {
+			if err != io.EOF {
+				logger.Debugf("[API G/W] Router > Stream closed: %s", err.Error())
+			}
+			return
+		}
+	}
+}
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,161 @@
+package gin
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/encoding"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+	"github.com/gin-gonic/gin"
+)
+
+// streamBackend - Backend Response Body를 Pipe로 구성해서 테스트에서 직접 전송할 수 있는 Proxy (Context 종료시 Body 종료)
+type streamBackend struct {
+	writer chan *io.PipeWriter
+	ctx    chan context.Context
+}
+
+func newStreamBackend() *streamBackend {
+	return &streamBackend{writer: make(chan *io.PipeWriter, 1), ctx: make(chan context.Context, 1)}
+}
+
+func (sb *streamBackend) proxy(ctx context.Context, _ *proxy.Request) (*proxy.Response, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		<-ctx.Done()
+		pw.CloseWithError(ctx.Err())
+	}()
+	sb.writer <- pw
+	sb.ctx <- ctx
+	return &proxy.Response{
+		Metadata:   proxy.Metadata{StatusCode: http.StatusOK, Headers: map[string][]string{"Content-Type": {"text/event-stream"}}},
+		Io:         pr,
+		IsComplete: true,
+	}, nil
+}
+
+// newStreamServer - 지정한 Streaming 설정의 Endpoint를 처리하는 테스트 서버 구성
+func newStreamServer(t *testing.T, streaming *config.StreamingConfig, sb *streamBackend) *httptest.Server {
+	eConf := &config.EndpointConfig{Endpoint: "/stream", Timeout: 50 * time.Millisecond, OutputEncoding: encoding.NOOP, Streaming: streaming}
+	engine := gin.New()
+	engine.GET(eConf.Endpoint, EndpointHandler(eConf, sb.proxy))
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// waitDone - 지정한 Context가 제한 시간 내에 종료되는지 검증
+func waitDone(t *testing.T, name string, ctx context.Context) {
+	t.Helper()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Errorf("%s: backend context should be canceled", name)
+	}
+}
+
+func TestStreamRender_flush(t *testing.T) {
+	sb := newStreamBackend()
+	server := newStreamServer(t, &config.StreamingConfig{}, sb)
+
+	resp, err := http.Get(server.URL + "/stream")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response: %d, %v", resp.StatusCode, resp.Header)
+	}
+
+	// 수신된 데이터는 Body 종료 전에 즉시 전달 (Endpoint Timeout은 Header 수신 이후 미 적용)
+	pw := <-sb.writer
+	br := bufio.NewReader(resp.Body)
+	for _, event := range []string{"data: 1\n", "data: 2\n"} {
+		time.Sleep(60 * time.Millisecond)
+		pw.Write([]byte(event))
+		line, err := br.ReadString('\n')
+		if err != nil || line != event {
+			t.Fatalf("event should be flushed: %q, %v", line, err)
+		}
+	}
+	pw.Close()
+	if rest, err := ioutil.ReadAll(br); err != nil || len(rest) != 0 {
+		t.Errorf("stream should be closed: %q, %v", rest, err)
+	}
+}
+
+func TestStreamRender_limits(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		streaming *config.StreamingConfig
+		keepAlive bool
+	}{
+		{name: "idle timeout", streaming: &config.StreamingConfig{IdleTimeout: 50 * time.Millisecond}},
+		// 데이터가 계속 수신되어도 최대 유지 시간 초과시 종료
+		{name: "max duration", streaming: &config.StreamingConfig{MaxDuration: 100 * time.Millisecond}, keepAlive: true},
+	} {
+		sb := newStreamBackend()
+		server := newStreamServer(t, tc.streaming, sb)
+
+		begin := time.Now()
+		resp, err := http.Get(server.URL + "/stream")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err.Error())
+		}
+		pw, ctx := <-sb.writer, <-sb.ctx
+		if tc.keepAlive {
+			go func() {
+				for {
+					if _, err := pw.Write([]byte("data: ping\n")); err != nil {
+						return
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}()
+		}
+
+		ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if elapsed := time.Since(begin); elapsed > time.Second {
+			t.Errorf("%s: stream should be closed, but %s", tc.name, elapsed)
+		}
+		waitDone(t, tc.name, ctx)
+	}
+}
+
+func TestStreamRender_clientClosed(t *testing.T) {
+	sb := newStreamBackend()
+	server := newStreamServer(t, &config.StreamingConfig{}, sb)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	// 클라이언트 연결 종료는 Backend로 전파
+	backendCtx := <-sb.ctx
+	cancel()
+	waitDone(t, "client closed", backendCtx)
+}
+
+func TestStreamRender_noStream(t *testing.T) {
+	for _, res := range []*proxy.Response{nil, {Data: map[string]interface{}{"a": 1}}} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		streamRender(c, res)
+		c.Writer.WriteHeaderNow()
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("%+v: response without stream should be 500: %d", res, w.Code)
+		}
+	}
+}
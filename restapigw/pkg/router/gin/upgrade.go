@@ -0,0 +1,154 @@
+package gin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/router"
+	"github.com/gin-gonic/gin"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// Upgrade 연결의 전송 방향 식별자 (클라이언트 -> Backend)
+	upgradeDirectionIn = "in"
+	// Upgrade 연결의 전송 방향 식별자 (Backend -> 클라이언트)
+	upgradeDirectionOut = "out"
+)
+
+// ===== [ Types ] =====
+
+// noopUpgradeObserver - Metrics 처리가 설정되지 않은 경우에 사용할 UpgradeObserver 구조
+type noopUpgradeObserver struct{}
+
+// ===== [ Implementations ] =====
+
+// UpgradeOpened - 처리 없음
+func (noopUpgradeObserver) UpgradeOpened() {}
+
+// UpgradeClosed - 처리 없음
+func (noopUpgradeObserver) UpgradeClosed() {}
+
+// UpgradeTransferred - 처리 없음
+func (noopUpgradeObserver) UpgradeTransferred(string, int64) {}
+
+// ===== [ Private Functions ] =====
+
+// isUpgradeRequest - 지정한 Request가 Protocol 전환 (WebSocket 등의 Upgrade) 요청인지 검증
+func isUpgradeRequest(req *http.Request) bool {
+	if req.Header.Get("Upgrade") == "" {
+		return false
+	}
+	for _, v := range req.Header["Connection"] {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isUpgradable - 지정한 Endpoint 설정이 Upgrade 연결 중계를 지원하는지 검증 (Bypass 또는 "websocket" 설정)
+func isUpgradable(eConf *config.EndpointConfig) bool {
+	return eConf.IsBypass || eConf.WebSocket != nil
+}
+
+// upgradedConn - Proxy 처리 결과가 Protocol 전환 응답인 경우 Backend 연결 반환
+func upgradedConn(response *proxy.Response) (io.ReadWriteCloser, bool) {
+	if response == nil || response.Metadata.StatusCode != http.StatusSwitchingProtocols {
+		return nil, false
+	}
+	backend, ok := response.Io.(io.ReadWriteCloser)
+	return backend, ok
+}
+
+// serveUpgrade - 클라이언트 연결을 Hijack 해서 Backend의 Protocol 전환 응답을 전달하고 양방향 데이터를 중계
+func serveUpgrade(c *gin.Context, eConf *config.EndpointConfig, response *proxy.Response, backend io.ReadWriteCloser) {
+	var observer router.UpgradeObserver = noopUpgradeObserver{}
+	if o, ok := c.Writer.(router.UpgradeObserver); ok {
+		observer = o
+	}
+
+	c.Status(http.StatusSwitchingProtocols)
+	conn, brw, err := c.Writer.Hijack()
+	if err != nil {
+		backend.Close()
+		logger.Errorf("[API G/W] Router > Can not hijack the connection for upgrade on %s: %s", eConf.Endpoint, err.Error())
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Backend의 Protocol 전환 응답을 클라이언트로 전달
+	fmt.Fprintf(brw, "HTTP/1.1 %d %s\r\n", http.StatusSwitchingProtocols, http.StatusText(http.StatusSwitchingProtocols))
+	http.Header(response.Metadata.Headers).Write(brw)
+	brw.WriteString("\r\n")
+	if err := brw.Flush(); err != nil {
+		conn.Close()
+		backend.Close()
+		return
+	}
+
+	idleTimeout := time.Duration(0)
+	if eConf.WebSocket != nil {
+		idleTimeout = eConf.WebSocket.IdleTimeout
+	}
+
+	observer.UpgradeOpened()
+	tunnel(conn, brw.Reader, backend, idleTimeout, observer)
+	observer.UpgradeClosed()
+}
+
+// tunnel - 클라이언트와 Backend 연결 간의 양방향 데이터 중계 (한쪽이 종료되거나 Idle Timeout 초과시 양쪽 모두 종료)
+func tunnel(client io.WriteCloser, clientReader *bufio.Reader, backend io.ReadWriteCloser, idleTimeout time.Duration, observer router.UpgradeObserver) {
+	var once sync.Once
+	closeAll := func() {
+		once.Do(func() {
+			client.Close()
+			backend.Close()
+		})
+	}
+
+	activity := func() {}
+	if idleTimeout > 0 {
+		timer := time.AfterFunc(idleTimeout, closeAll)
+		defer timer.Stop()
+		activity = func() { timer.Reset(idleTimeout) }
+	}
+
+	done := make(chan struct{}, 2)
+	pipe := func(dst io.Writer, src io.Reader, direction string) {
+		buf := make([]byte, streamBufferSize)
+		for {
+			n, err := src.Read(buf)
+			if n > 0 {
+				if _, werr := dst.Write(buf[:n]); werr != nil {
+					break
+				}
+				activity()
+				observer.UpgradeTransferred(direction, int64(n))
+			}
+			if err != nil {
+				break
+			}
+		}
+		done <- struct{}{}
+	}
+
+	go pipe(backend, clientReader, upgradeDirectionIn)
+	go pipe(client, backend, upgradeDirectionOut)
+
+	<-done
+	closeAll()
+	<-done
+}
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,214 @@
+package gin
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+	"github.com/gin-gonic/gin"
+)
+
+// countingObserver - Upgrade 연결의 상태와 방향 별 전송량을 기록하는 UpgradeObserver
+type countingObserver struct {
+	mu          sync.Mutex
+	opened      int
+	closed      int
+	transferred map[string]int64
+}
+
+func (co *countingObserver) UpgradeOpened() { co.mu.Lock(); co.opened++; co.mu.Unlock() }
+func (co *countingObserver) UpgradeClosed() { co.mu.Lock(); co.closed++; co.mu.Unlock() }
+func (co *countingObserver) UpgradeTransferred(direction string, n int64) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.transferred[direction] += n
+}
+
+// echoBackend - Protocol 전환 응답과 수신한 데이터를 그대로 반환하는 Backend 연결을 제공하는 Proxy
+func echoBackend(closed chan struct{}) proxy.Proxy {
+	return func(_ context.Context, _ *proxy.Request) (*proxy.Response, error) {
+		conn, peer := net.Pipe()
+		go func() {
+			io.Copy(peer, peer)
+			peer.Close()
+			close(closed)
+		}()
+		return &proxy.Response{
+			Metadata:   proxy.Metadata{StatusCode: http.StatusSwitchingProtocols, Headers: map[string][]string{"Upgrade": {"echo"}, "Connection": {"Upgrade"}}},
+			Io:         conn,
+			IsComplete: true,
+		}, nil
+	}
+}
+
+// dialUpgrade - 테스트 서버에 Upgrade 요청을 전송하고 연결과 응답 반환
+func dialUpgrade(t *testing.T, server *httptest.Server, path string) (net.Conn, *bufio.Reader, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: gateway\r\nConnection: keep-alive, Upgrade\r\nUpgrade: echo\r\n\r\n", path)
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return conn, br, resp
+}
+
+func TestIsUpgradeRequest(t *testing.T) {
+	for _, tc := range []struct {
+		connection []string
+		upgrade    string
+		expected   bool
+	}{
+		{connection: []string{"Upgrade"}, upgrade: "websocket", expected: true},
+		{connection: []string{"keep-alive, upgrade"}, upgrade: "websocket", expected: true},
+		{connection: []string{"keep-alive", "Upgrade"}, upgrade: "h2c", expected: true},
+		{connection: []string{"keep-alive"}, upgrade: "websocket"},
+		{connection: []string{"Upgrade"}},
+		{upgrade: "websocket"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		req.Header["Connection"] = tc.connection
+		if tc.upgrade != "" {
+			req.Header.Set("Upgrade", tc.upgrade)
+		}
+		if got := isUpgradeRequest(req); got != tc.expected {
+			t.Errorf("%v, %q: unexpected result %v", tc.connection, tc.upgrade, got)
+		}
+	}
+}
+
+func TestUpgrade_endpoint(t *testing.T) {
+	closed := make(chan struct{})
+	engine := gin.New()
+	engine.GET("/ws", EndpointHandler(&config.EndpointConfig{Endpoint: "/ws", Timeout: time.Second, WebSocket: &config.WebSocketConfig{}}, echoBackend(closed)))
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	conn, br, resp := dialUpgrade(t, server, "/ws")
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Upgrade") != "echo" {
+		t.Fatalf("backend upgrade response should be passed: %d, %v", resp.StatusCode, resp.Header)
+	}
+
+	// Endpoint Timeout 이후에도 양방향 데이터 중계
+	for _, msg := range []string{"ping", "pong"} {
+		conn.Write([]byte(msg))
+		buf := make([]byte, len(msg))
+		if _, err := io.ReadFull(br, buf); err != nil || string(buf) != msg {
+			t.Fatalf("data should be tunnelled: %q, %v", buf, err)
+		}
+	}
+
+	// 클라이언트 연결 종료시 Backend 연결 종료
+	conn.Close()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Error("backend connection should be closed")
+	}
+}
+
+func TestUpgrade_notUpgradable(t *testing.T) {
+	closed := make(chan struct{})
+	engine := gin.New()
+	engine.GET("/ws", EndpointHandler(&config.EndpointConfig{Endpoint: "/ws", Timeout: time.Second}, echoBackend(closed)))
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	// Bypass 또는 "websocket" 설정이 없는 Endpoint는 Protocol 전환 응답을 중계하지 않음
+	_, _, resp := dialUpgrade(t, server, "/ws")
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("upgrade should be rejected: %d", resp.StatusCode)
+	}
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Error("backend connection should be closed")
+	}
+}
+
+func TestTunnel(t *testing.T) {
+	client, clientPeer := net.Pipe()
+	backend, backendPeer := net.Pipe()
+	observer := &countingObserver{transferred: map[string]int64{}}
+
+	done := make(chan struct{})
+	go func() {
+		tunnel(client, bufio.NewReader(client), backend, 0, observer)
+		close(done)
+	}()
+
+	clientPeer.Write([]byte("hello"))
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(backendPeer, buf); err != nil || string(buf) != "hello" {
+		t.Fatalf("client data should be sent to backend: %q, %v", buf, err)
+	}
+	backendPeer.Write([]byte("hi"))
+	if _, err := io.ReadFull(clientPeer, buf[:2]); err != nil || string(buf[:2]) != "hi" {
+		t.Fatalf("backend data should be sent to client: %q, %v", buf[:2], err)
+	}
+
+	// 한쪽 연결이 종료되면 양쪽 모두 종료
+	backendPeer.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("tunnel should be closed")
+	}
+	if _, err := clientPeer.Read(buf); err == nil {
+		t.Error("client connection should be closed")
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if observer.transferred[upgradeDirectionIn] != 5 || observer.transferred[upgradeDirectionOut] != 2 {
+		t.Errorf("unexpected transferred bytes: %v", observer.transferred)
+	}
+}
+
+func TestTunnel_idleTimeout(t *testing.T) {
+	client, clientPeer := net.Pipe()
+	backend, backendPeer := net.Pipe()
+	defer clientPeer.Close()
+	defer backendPeer.Close()
+
+	done := make(chan struct{})
+	go func() {
+		tunnel(client, bufio.NewReader(client), backend, 50*time.Millisecond, noopUpgradeObserver{})
+		close(done)
+	}()
+
+	// 데이터 전송은 Idle Timeout 재 설정
+	buf := make([]byte, 1)
+	for i := 0; i < 3; i++ {
+		time.Sleep(30 * time.Millisecond)
+		clientPeer.Write([]byte("x"))
+		backendPeer.Read(buf)
+	}
+	select {
+	case <-done:
+		t.Fatal("active tunnel should not be closed")
+	default:
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("idle tunnel should be closed")
+	}
+}
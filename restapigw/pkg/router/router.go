@@ -24,6 +24,8 @@ var (
 	MessageResponseHeaderName = "X-" + core.AppName + "-Messages"
 	// CompleteResponseHeaderName - 정상/비정상 종료에 대한 Header 정보를 클라이언트에 알리기 위한 Header 명
 	CompleteResponseHeaderName = "X-" + core.AppName + "-Completed"
+	// ErrorsResponseHeaderName - 여러 Backend Merging 중에 발생한 Backend 별 오류 정보 (JSON)를 클라이언트에 알리기 위한 Header 명
+	ErrorsResponseHeaderName = "X-" + core.AppName + "-Errors"
 	// HeadersToSend - Route로 전달된 Request에서 Proxy로 전달할 설정에 지정된 Header들 정보
 	HeadersToSend = []string{"Content-Type"}
 	// HeadersToNotSend - Router로 전달된 Request에서 Proxy로 전달죄지 않을 Header들 정보
@@ -56,6 +58,13 @@ type (
 		RegisterAPIs(sConf *config.ServiceConfig, defs []*config.EndpointConfig) error
 	}
 
+	// UpgradeObserver - Upgrade 처리된 연결 (WebSocket 등) 의 상태와 전송량을 수집하기 위한 인터페이스 (Metrics 처리용 ResponseWriter에서 구현)
+	UpgradeObserver interface {
+		UpgradeOpened()
+		UpgradeClosed()
+		UpgradeTransferred(direction string, n int64)
+	}
+
 	// DynamicRouter - 동적 라우팅 구성을 위한 Routing Engine 구조
 	DynamicRouter struct {
 		handler http.Handler
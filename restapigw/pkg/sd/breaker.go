@@ -0,0 +1,452 @@
+// Package sd - Backend Host 별 Circuit Breaker 처리 패키지
+package sd
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// BreakerClosed - 정상적으로 호출하는 상태
+	BreakerClosed BreakerState = iota
+	// BreakerOpen - 호출 실패로 Load Balancing 대상에서 제외된 상태
+	BreakerOpen
+	// BreakerHalfOpen - Cool-down 이후 시험 호출로 복구 여부를 검증하는 상태
+	BreakerHalfOpen
+)
+
+const (
+	// HostSucceeded - Host 호출 성공
+	HostSucceeded HostResult = iota
+	// HostFailed - Host 호출 실패 (Network 오류, 처리 시간 초과, 5xx 응답)
+	HostFailed
+	// HostIgnored - 클라이언트 요청 취소 등으로 Host 상태 판단에 사용하지 않는 결과
+	HostIgnored
+)
+
+const (
+	// Circuit 상태로 인해 선택된 Host가 거부된 경우 다시 선택할 최대 횟수
+	breakerSelectRetries = 3
+)
+
+var (
+	// ErrAllHostsOpen - 모든 Backend Host의 Circuit이 Open 상태라서 호출할 수 없는 경우 오류
+	ErrAllHostsOpen = core.NewWrappedError(http.StatusServiceUnavailable, "all backend hosts are unavailable (circuit open)", nil)
+
+	// Admin API 상태 조회와 Router 재 구성시의 상태 유지를 위한 Balancer 별 Circuit Breaker 관리
+	breakers = &breakerRegistry{}
+)
+
+// ===== [ Types ] =====
+
+type (
+	// BreakerState - Circuit Breaker 상태 형식
+	BreakerState int
+
+	// HostResult - Balancer가 선택한 Host의 호출 결과 형식
+	HostResult int
+
+	// BreakerReporter - Circuit 상태 전환을 수집하는 함수 형식 (Metrics 연계용)
+	BreakerReporter func(name, host string, from, to BreakerState)
+
+	// HostSelector - 선택한 Host와 함께 호출 결과를 전달할 함수를 반환하는 Balancer 인터페이스 (Circuit Breaker 연계용)
+	// (호출 결과는 Host 선택 당시의 Circuit 상태에 대해서만 반영되므로 반드시 한번 호출해야 함)
+	HostSelector interface {
+		Select() (string, func(HostResult), error)
+	}
+
+	// BreakerStatus - Admin API로 제공하는 Circuit Breaker 상태 정보 구조
+	BreakerStatus struct {
+		Name                string     `json:"name"`
+		Host                string     `json:"host"`
+		State               string     `json:"state"`
+		ConsecutiveFailures int        `json:"consecutive_failures"`
+		Requests            int        `json:"requests"`
+		Failures            int        `json:"failures"`
+		OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	}
+
+	// circuitBreaker - 단일 Backend Host의 Circuit 상태 관리 구조
+	circuitBreaker struct {
+		mu     sync.Mutex
+		name   string
+		host   string
+		conf   *config.CircuitBreakerConfig
+		report BreakerReporter
+
+		state       BreakerState
+		generation  uint64
+		consecutive int
+		requests    int
+		failures    int
+		windowStart time.Time
+		openedAt    time.Time
+		probes      int
+		successes   int
+	}
+
+	// breakerGroup - Balancer의 Host 별 Circuit Breaker 관리 구조
+	breakerGroup struct {
+		mu     sync.RWMutex
+		key    string
+		name   string
+		conf   *config.CircuitBreakerConfig
+		report BreakerReporter
+		items  map[string]*circuitBreaker
+	}
+
+	// breakerRegistry - 현재 Router 구성의 Balancer 별 Circuit Breaker 그룹 관리 구조 (이전 구성의 그룹은 재 사용을 위해 한번의 재 구성 동안만 유지)
+	breakerRegistry struct {
+		mu       sync.Mutex
+		current  []*breakerGroup
+		previous map[string][]*breakerGroup
+	}
+
+	// breakerBalancer - Circuit이 Open 된 Host를 제외하고 선택하는 Balancer 구조
+	breakerBalancer struct {
+		Balancer
+		group *breakerGroup
+	}
+
+	// breakerSubscriber - Circuit이 Open 된 Host를 제외한 Hosts를 제공하는 Subscriber 구조
+	breakerSubscriber struct {
+		Subscriber
+		bb *breakerBalancer
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// String - Circuit 상태 명 반환
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// setState - Circuit 상태 전환 및 전환 정보 전달 (Lock 상태에서 호출)
+func (cb *circuitBreaker) setState(to BreakerState, now time.Time) {
+	from := cb.state
+	if from == to {
+		return
+	}
+
+	cb.state = to
+	cb.generation++
+	cb.consecutive, cb.requests, cb.failures = 0, 0, 0
+	cb.windowStart = now
+	cb.probes, cb.successes = 0, 0
+	if to == BreakerOpen {
+		cb.openedAt = now
+	}
+
+	logger := logging.GetLogger()
+	if to == BreakerOpen {
+		logger.Warnf("[MIDDLEWARE] Circuit Breaker > %s (%s) state changed: %s -> %s", cb.name, cb.host, from, to)
+	} else {
+		logger.Infof("[MIDDLEWARE] Circuit Breaker > %s (%s) state changed: %s -> %s", cb.name, cb.host, from, to)
+	}
+	if cb.report != nil {
+		cb.report(cb.name, cb.host, from, to)
+	}
+}
+
+// ready - Host를 Load Balancing 대상에 포함할 수 있는지 검증 (상태 변경 없음)
+func (cb *circuitBreaker) ready(now time.Time) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case BreakerOpen:
+		return now.Sub(cb.openedAt) >= cb.conf.CoolDown
+	case BreakerHalfOpen:
+		return cb.probes < cb.conf.HalfOpenRequests
+	default:
+		return true
+	}
+}
+
+// allow - 선택된 Host의 호출 허용 여부와 허용 당시의 상태 세대 반환 (Cool-down 이 지난 경우는 Half-Open 상태로 전환하고 시험 호출 허용)
+func (cb *circuitBreaker) allow(now time.Time) (uint64, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case BreakerOpen:
+		if now.Sub(cb.openedAt) < cb.conf.CoolDown {
+			return 0, false
+		}
+		cb.setState(BreakerHalfOpen, now)
+		fallthrough
+	case BreakerHalfOpen:
+		if cb.probes >= cb.conf.HalfOpenRequests {
+			return 0, false
+		}
+		cb.probes++
+		return cb.generation, true
+	default:
+		return cb.generation, true
+	}
+}
+
+// record - 지정한 상태 세대에 허용된 Host 호출 결과를 반영해서 Circuit 상태 전환
+// (상태 전환 이전에 허용된 호출의 결과는 현재 상태의 판단이나 시험 호출에 사용하지 않도록 무시)
+func (cb *circuitBreaker) record(generation uint64, result HostResult, now time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if generation != cb.generation {
+		return
+	}
+
+	switch cb.state {
+	case BreakerHalfOpen:
+		if cb.probes > 0 {
+			cb.probes--
+		}
+		switch result {
+		case HostFailed:
+			cb.setState(BreakerOpen, now)
+		case HostSucceeded:
+			cb.successes++
+			if cb.successes >= cb.conf.HalfOpenRequests {
+				cb.setState(BreakerClosed, now)
+			}
+		}
+	case BreakerClosed:
+		if result == HostIgnored {
+			return
+		}
+		if cb.conf.Interval > 0 && now.Sub(cb.windowStart) >= cb.conf.Interval {
+			cb.requests, cb.failures = 0, 0
+			cb.windowStart = now
+		}
+
+		cb.requests++
+		if result == HostFailed {
+			cb.failures++
+			cb.consecutive++
+		} else {
+			cb.consecutive = 0
+		}
+
+		if (cb.conf.ConsecutiveFailures > 0 && cb.consecutive >= cb.conf.ConsecutiveFailures) ||
+			(cb.conf.FailureRatio > 0 && cb.requests >= cb.conf.MinRequests && float64(cb.failures)/float64(cb.requests) >= cb.conf.FailureRatio) {
+			cb.setState(BreakerOpen, now)
+		}
+	}
+}
+
+// status - Admin API 제공용 상태 정보 반환
+func (cb *circuitBreaker) status() BreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	bs := BreakerStatus{
+		Name:                cb.name,
+		Host:                cb.host,
+		State:               cb.state.String(),
+		ConsecutiveFailures: cb.consecutive,
+		Requests:            cb.requests,
+		Failures:            cb.failures,
+	}
+	if cb.state != BreakerClosed {
+		openedAt := cb.openedAt
+		bs.OpenedAt = &openedAt
+	}
+	return bs
+}
+
+// breaker - 지정한 Host의 Circuit Breaker 반환 (없는 경우는 생성)
+func (bg *breakerGroup) breaker(host string) *circuitBreaker {
+	bg.mu.RLock()
+	cb, ok := bg.items[host]
+	bg.mu.RUnlock()
+	if ok {
+		return cb
+	}
+
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+	if cb, ok = bg.items[host]; !ok {
+		cb = &circuitBreaker{name: bg.name, host: host, conf: bg.conf, report: bg.report, windowStart: time.Now()}
+		bg.items[host] = cb
+	}
+	return cb
+}
+
+// retain - Subscriber가 제공하지 않는 (Service Discovery 에서 제외된) Host의 Circuit Breaker 제거
+func (bg *breakerGroup) retain(hosts []*config.HostConfig) {
+	bg.mu.RLock()
+	stale := len(bg.items) > len(hosts)
+	bg.mu.RUnlock()
+	if !stale {
+		return
+	}
+
+	alive := make(map[string]bool, len(hosts))
+	for _, h := range hosts {
+		alive[h.Host] = true
+	}
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+	for host := range bg.items {
+		if !alive[host] {
+			delete(bg.items, host)
+		}
+	}
+}
+
+// setReporter - 관리 중인 Circuit Breaker들의 상태 전환 Reporter 변경
+func (bg *breakerGroup) setReporter(report BreakerReporter) {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+
+	bg.report = report
+	for _, cb := range bg.items {
+		cb.mu.Lock()
+		cb.report = report
+		cb.mu.Unlock()
+	}
+}
+
+// register - Balancer에서 사용할 Circuit Breaker 그룹 반환 (이전 Router 구성에 같은 Backend와 설정의 그룹이 있으면 상태 유지를 위해 재 사용)
+func (br *breakerRegistry) register(key, name string, conf *config.CircuitBreakerConfig, report BreakerReporter) *breakerGroup {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	var bg *breakerGroup
+	if groups := br.previous[key]; len(groups) > 0 {
+		bg, br.previous[key] = groups[0], groups[1:]
+		bg.setReporter(report)
+	} else {
+		bg = &breakerGroup{key: key, name: name, conf: conf, report: report, items: map[string]*circuitBreaker{}}
+	}
+	br.current = append(br.current, bg)
+	return bg
+}
+
+// reset - 현재 그룹들을 이전 구성으로 전환 (재 사용되지 않은 이전 구성의 그룹은 제거)
+func (br *breakerRegistry) reset() {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	previous := map[string][]*breakerGroup{}
+	for _, bg := range br.current {
+		previous[bg.key] = append(previous[bg.key], bg)
+	}
+	br.current, br.previous = nil, previous
+}
+
+// statuses - 현재 Router 구성의 모든 Circuit Breaker 상태 정보 반환 (Backend, Host 순서 정렬)
+func (br *breakerRegistry) statuses() []BreakerStatus {
+	br.mu.Lock()
+	groups := make([]*breakerGroup, len(br.current))
+	copy(groups, br.current)
+	br.mu.Unlock()
+
+	result := []BreakerStatus{}
+	for _, bg := range groups {
+		bg.mu.RLock()
+		for _, cb := range bg.items {
+			result = append(result, cb.status())
+		}
+		bg.mu.RUnlock()
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Host < result[j].Host
+	})
+	return result
+}
+
+// Select - Circuit이 Open 되지 않은 Host와 호출 결과 전달 함수 반환 (모두 Open 된 경우는 ErrAllHostsOpen)
+func (bb *breakerBalancer) Select() (string, func(HostResult), error) {
+	for i := 0; i < breakerSelectRetries; i++ {
+		host, err := bb.Balancer.Host()
+		if err != nil {
+			return "", nil, err
+		}
+		cb := bb.group.breaker(host)
+		if generation, ok := cb.allow(time.Now()); ok {
+			return host, func(result HostResult) { cb.record(generation, result, time.Now()) }, nil
+		}
+	}
+	return "", nil, ErrAllHostsOpen
+}
+
+// Host - Circuit이 Open 되지 않은 Host 선택 (호출 결과를 전달할 수 없으므로 시험 호출로 사용하지 않음, 결과 전달이 필요한 경우는 Select 사용)
+func (bb *breakerBalancer) Host() (string, error) {
+	host, done, err := bb.Select()
+	if err != nil {
+		return "", err
+	}
+	done(HostIgnored)
+	return host, nil
+}
+
+// Hosts - Circuit이 Open 되지 않은 Hosts 반환 (모두 Open 된 경우는 ErrAllHostsOpen)
+func (bs breakerSubscriber) Hosts() ([]*config.HostConfig, error) {
+	hosts, err := bs.Subscriber.Hosts()
+	if err != nil || len(hosts) == 0 {
+		return hosts, err
+	}
+
+	now := time.Now()
+	available := make([]*config.HostConfig, 0, len(hosts))
+	for _, h := range hosts {
+		if bs.bb.group.breaker(h.Host).ready(now) {
+			available = append(available, h)
+		}
+	}
+	bs.bb.group.retain(hosts)
+	if len(available) == 0 {
+		return nil, ErrAllHostsOpen
+	}
+	return available, nil
+}
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// NewBalancerWithBreaker - 지정한 Circuit Breaker 설정에 따라 Circuit이 Open 된 Host를 제외하고 선택하는 Load Balancer 생성
+// (Circuit Breaker 설정이 없으면 일반 Load Balancer, Host 별 호출 결과는 HostSelector 인터페이스로 전달)
+func NewBalancerWithBreaker(subscriber Subscriber, name string, conf *config.CircuitBreakerConfig, report BreakerReporter) Balancer {
+	if conf == nil {
+		return NewBalancer(subscriber)
+	}
+
+	// 같은 Backend라도 Circuit Breaker 설정이 다르면 별도로 관리 (설정이 같으면 Router 재 구성시에도 상태 유지)
+	bb := &breakerBalancer{group: breakers.register(fmt.Sprintf("%s|%+v", name, *conf), name, conf, report)}
+	bb.Balancer = NewBalancer(breakerSubscriber{Subscriber: subscriber, bb: bb})
+	return bb
+}
+
+// BreakerStatuses - 현재 Router 구성에서 관리 중인 모든 Backend Host의 Circuit Breaker 상태 정보 반환
+func BreakerStatuses() []BreakerStatus {
+	return breakers.statuses()
+}
+
+// ResetBreakers - Router 재 구성 전에 호출해서 현재 Circuit Breaker들을 이전 구성으로 전환
+// (재 구성된 Balancer가 같은 Backend와 설정이면 상태를 이어서 사용하고, 사용되지 않은 이전 구성은 다음 재 구성시 제거)
+func ResetBreakers() {
+	breakers.reset()
+}
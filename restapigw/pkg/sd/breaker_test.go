@@ -0,0 +1,278 @@
+package sd
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+)
+
+func init() {
+	// Circuit 상태 전환 로그 출력용
+	logging.NewLogger()
+}
+
+// testSubscriber - Hosts를 변경할 수 있는 Round Robin Subscriber
+type testSubscriber struct {
+	mu    sync.Mutex
+	hosts []*config.HostConfig
+}
+
+func (ts *testSubscriber) Mode() string { return "rr" }
+
+func (ts *testSubscriber) Hosts() ([]*config.HostConfig, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.hosts, nil
+}
+
+func (ts *testSubscriber) set(hosts ...string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.hosts = ts.hosts[:0]
+	for _, h := range hosts {
+		ts.hosts = append(ts.hosts, &config.HostConfig{Host: h})
+	}
+}
+
+func newTestBreaker(conf *config.CircuitBreakerConfig, now time.Time) *circuitBreaker {
+	return &circuitBreaker{name: "/test", host: "http://a", conf: conf, windowStart: now}
+}
+
+func mustAllow(t *testing.T, cb *circuitBreaker, now time.Time) uint64 {
+	t.Helper()
+	gen, ok := cb.allow(now)
+	if !ok {
+		t.Fatalf("call should be allowed in %s state", cb.state)
+	}
+	return gen
+}
+
+func TestCircuitBreaker_consecutiveFailures(t *testing.T) {
+	now := time.Now()
+	cb := newTestBreaker(&config.CircuitBreakerConfig{ConsecutiveFailures: 3, CoolDown: time.Second, HalfOpenRequests: 1}, now)
+
+	for i := 0; i < 2; i++ {
+		cb.record(mustAllow(t, cb, now), HostFailed, now)
+	}
+	// 성공하면 연속 실패 횟수 초기화
+	cb.record(mustAllow(t, cb, now), HostSucceeded, now)
+	for i := 0; i < 2; i++ {
+		cb.record(mustAllow(t, cb, now), HostFailed, now)
+	}
+	// 무시되는 결과는 연속 실패 횟수에 영향 없음
+	cb.record(mustAllow(t, cb, now), HostIgnored, now)
+	if cb.state != BreakerClosed {
+		t.Fatalf("unexpected state: %s", cb.state)
+	}
+
+	cb.record(mustAllow(t, cb, now), HostFailed, now)
+	if cb.state != BreakerOpen {
+		t.Fatalf("circuit should be open after 3 consecutive failures, but %s", cb.state)
+	}
+	if _, ok := cb.allow(now.Add(500 * time.Millisecond)); ok {
+		t.Error("call should be rejected during cool-down")
+	}
+	if cb.ready(now.Add(500 * time.Millisecond)) {
+		t.Error("host should not be ready during cool-down")
+	}
+	if !cb.ready(now.Add(time.Second)) {
+		t.Error("host should be ready after cool-down")
+	}
+}
+
+func TestCircuitBreaker_failureRatio(t *testing.T) {
+	now := time.Now()
+	cb := newTestBreaker(&config.CircuitBreakerConfig{FailureRatio: 0.5, MinRequests: 4, Interval: time.Minute, CoolDown: time.Second, HalfOpenRequests: 1}, now)
+
+	for _, r := range []HostResult{HostFailed, HostSucceeded, HostFailed} {
+		cb.record(mustAllow(t, cb, now), r, now)
+	}
+	if cb.state != BreakerClosed {
+		t.Fatalf("circuit should stay closed below min_requests, but %s", cb.state)
+	}
+
+	// 산정 주기가 지나면 호출/실패 수 초기화
+	later := now.Add(time.Minute)
+	cb.record(mustAllow(t, cb, later), HostFailed, later)
+	if cb.state != BreakerClosed || cb.requests != 1 {
+		t.Fatalf("window should be reset, state: %s, requests: %d", cb.state, cb.requests)
+	}
+	for _, r := range []HostResult{HostSucceeded, HostSucceeded, HostFailed} {
+		cb.record(mustAllow(t, cb, later), r, later)
+	}
+	if cb.state != BreakerOpen {
+		t.Fatalf("circuit should be open at 50%% failures, but %s", cb.state)
+	}
+}
+
+func TestCircuitBreaker_halfOpen(t *testing.T) {
+	conf := &config.CircuitBreakerConfig{ConsecutiveFailures: 1, CoolDown: time.Second, HalfOpenRequests: 2}
+
+	for _, tc := range []struct {
+		name     string
+		results  []HostResult
+		expected BreakerState
+	}{
+		{name: "all probes succeeded", results: []HostResult{HostSucceeded, HostSucceeded}, expected: BreakerClosed},
+		{name: "probe failed", results: []HostResult{HostSucceeded, HostFailed}, expected: BreakerOpen},
+		{name: "probe ignored", results: []HostResult{HostSucceeded, HostIgnored}, expected: BreakerHalfOpen},
+	} {
+		now := time.Now()
+		cb := newTestBreaker(conf, now)
+		cb.record(mustAllow(t, cb, now), HostFailed, now)
+
+		probeAt := now.Add(time.Second)
+		gens := []uint64{mustAllow(t, cb, probeAt), mustAllow(t, cb, probeAt)}
+		if cb.state != BreakerHalfOpen {
+			t.Fatalf("%s: circuit should be half-open after cool-down, but %s", tc.name, cb.state)
+		}
+		if _, ok := cb.allow(probeAt); ok {
+			t.Fatalf("%s: probes should be limited to half_open_requests", tc.name)
+		}
+		if cb.ready(probeAt) {
+			t.Fatalf("%s: host should not be ready while all probes are in flight", tc.name)
+		}
+
+		for i, r := range tc.results {
+			cb.record(gens[i], r, probeAt)
+		}
+		if cb.state != tc.expected {
+			t.Errorf("%s: unexpected state: %s", tc.name, cb.state)
+		}
+	}
+}
+
+func TestCircuitBreaker_ignoreStaleResults(t *testing.T) {
+	now := time.Now()
+	cb := newTestBreaker(&config.CircuitBreakerConfig{ConsecutiveFailures: 1, CoolDown: time.Second, HalfOpenRequests: 1}, now)
+
+	// Open 이전에 허용된 호출들
+	staleFailure := mustAllow(t, cb, now)
+	staleSuccess := mustAllow(t, cb, now)
+	cb.record(mustAllow(t, cb, now), HostFailed, now)
+
+	// Open 상태에서 전달된 이전 결과는 무시
+	cb.record(staleSuccess, HostSucceeded, now)
+	if cb.state != BreakerOpen {
+		t.Fatalf("stale success should not close the circuit, but %s", cb.state)
+	}
+
+	probeAt := now.Add(time.Second)
+	probe := mustAllow(t, cb, probeAt)
+
+	// Half-Open 상태에서 전달된 이전 결과는 시험 호출로 사용하지 않음
+	cb.record(staleFailure, HostFailed, probeAt)
+	if cb.state != BreakerHalfOpen {
+		t.Fatalf("stale failure should not re-open the circuit, but %s", cb.state)
+	}
+	cb.record(staleSuccess, HostSucceeded, probeAt)
+	if cb.state != BreakerHalfOpen || cb.probes != 1 {
+		t.Fatalf("stale success should not settle the probe, state: %s, probes: %d", cb.state, cb.probes)
+	}
+
+	cb.record(probe, HostSucceeded, probeAt)
+	if cb.state != BreakerClosed {
+		t.Fatalf("circuit should be closed by the probe, but %s", cb.state)
+	}
+}
+
+func TestBreakerBalancer_selectAndReport(t *testing.T) {
+	ResetBreakers()
+	ResetBreakers()
+	ts := &testSubscriber{}
+	ts.set("http://a", "http://b")
+	var transitions []BreakerState
+	sb := NewBalancerWithBreaker(ts, "/select", &config.CircuitBreakerConfig{ConsecutiveFailures: 1, CoolDown: time.Hour, HalfOpenRequests: 1},
+		func(_, host string, _, to BreakerState) { transitions = append(transitions, to) })
+
+	hs, ok := sb.(HostSelector)
+	if !ok {
+		t.Fatal("balancer with breaker should implement HostSelector")
+	}
+	host, done, err := hs.Select()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	done(HostFailed)
+	if len(transitions) != 1 || transitions[0] != BreakerOpen {
+		t.Fatalf("unexpected transitions: %v", transitions)
+	}
+
+	// Open 된 Host는 선택 대상에서 제외
+	for i := 0; i < 10; i++ {
+		other, done, err := hs.Select()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if other == host {
+			t.Fatalf("open host %s should not be selected", host)
+		}
+		done(HostSucceeded)
+	}
+
+	// 남은 Host도 Open 되면 선택 불가
+	_, done, _ = hs.Select()
+	done(HostFailed)
+	if _, _, err := hs.Select(); err != ErrAllHostsOpen {
+		t.Fatalf("expected ErrAllHostsOpen, but %v", err)
+	}
+}
+
+func openBreakers() int {
+	opened := 0
+	for _, s := range BreakerStatuses() {
+		if s.State == BreakerOpen.String() {
+			opened++
+		}
+	}
+	return opened
+}
+
+func TestBreakerRegistry_lifecycle(t *testing.T) {
+	ResetBreakers()
+	ResetBreakers()
+	conf := &config.CircuitBreakerConfig{ConsecutiveFailures: 1, CoolDown: time.Hour, HalfOpenRequests: 1}
+	ts := &testSubscriber{}
+	ts.set("http://a", "http://b")
+
+	sb := NewBalancerWithBreaker(ts, "/registry", conf, nil).(HostSelector)
+	_, done, _ := sb.Select()
+	done(HostFailed)
+	if got := len(BreakerStatuses()); got != 2 || openBreakers() != 1 {
+		t.Fatalf("unexpected statuses: %+v", BreakerStatuses())
+	}
+
+	// 같은 Backend와 설정으로 재 구성된 Balancer는 상태 유지
+	ResetBreakers()
+	sb = NewBalancerWithBreaker(ts, "/registry", conf, nil).(HostSelector)
+	if openBreakers() != 1 {
+		t.Fatalf("open state should be kept for the rebuilt balancer: %+v", BreakerStatuses())
+	}
+
+	// Service Discovery 에서 제외된 Host는 제거
+	ts.set("http://c")
+	if _, done, err := sb.Select(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	} else {
+		done(HostSucceeded)
+	}
+	if statuses := BreakerStatuses(); len(statuses) != 1 || statuses[0].Host != "http://c" {
+		t.Fatalf("stale hosts should be pruned: %+v", statuses)
+	}
+
+	// 설정이 변경된 Balancer는 새로운 상태로 시작하고, 재 사용되지 않은 이전 구성은 다음 재 구성시 제거
+	ResetBreakers()
+	changed := *conf
+	changed.ConsecutiveFailures = 2
+	NewBalancerWithBreaker(ts, "/registry", &changed, nil)
+	if statuses := BreakerStatuses(); len(statuses) != 0 {
+		t.Fatalf("previous breakers should not be reported: %+v", statuses)
+	}
+	ResetBreakers()
+	if len(breakers.previous) != 1 {
+		t.Errorf("unused breakers should be dropped on the next rebuild: %v", breakers.previous)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+	bulkheadProxy "github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/bulkhead/proxy"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/httpcache"
 	ginMetrics "github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/metrics/gin"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/opencensus"
@@ -18,10 +19,9 @@ import (
 // ===== [ Types ] =====
 // ===== [ Implementations ] =====
 // ===== [ Private Functions ] =====
-// ===== [ Public Functions ] =====
 
-// setupGinBackendFactoryWithContext - 지정한 Context 기반으로 Middleware 들이 적용된 BackendFactory 설정
-func setupGinBackendFactoryWithContext(ctx context.Context, logger logging.Logger, mc *ginMetrics.Collector) proxy.BackendFactory {
+// setupGinHTTPBackendFactory - Middleware 들이 적용되지 않은 Opencensus HTTPRequestExecutor 기반의 Base BackendFactory 설정 (Mirror Shadow 요청에도 사용)
+func setupGinHTTPBackendFactory() proxy.BackendFactory {
 	requestExecutorFactory := func(bConf *config.BackendConfig) client.HTTPRequestExecutor {
 		// TODO: Backend Auth
 		// var clientFactory client.HTTPClientFactory
@@ -32,17 +32,28 @@ func setupGinBackendFactoryWithContext(ctx context.Context, logger logging.Logge
 		return opencensus.HTTPRequestExecutor(clientFactory)
 	}
 
-	// Opencensus HTTPRequestExecutor를 사용하는 Base BackendFactory 설정
-	backendFactory := func(bConf *config.BackendConfig) proxy.Proxy {
+	return func(bConf *config.BackendConfig) proxy.Proxy {
 		return proxy.NewHTTPProxyWithHTTPExecutor(bConf, requestExecutorFactory(bConf), bConf.Decoder)
 	}
+}
+
+// ===== [ Public Functions ] =====
+
+// setupGinBackendFactoryWithContext - 지정한 Context 기반으로 Middleware 들이 적용된 BackendFactory 설정
+func setupGinBackendFactoryWithContext(ctx context.Context, logger logging.Logger, mc *ginMetrics.Collector) proxy.BackendFactory {
+	// Opencensus HTTPRequestExecutor를 사용하는 Base BackendFactory 설정
+	backendFactory := setupGinHTTPBackendFactory()
+
+	// Host 없이 Mock 설정이 지정된 Backend는 고정 응답을 반환하는 BackendFactory 설정
+	backendFactory = proxy.NewMockBackendFactory(backendFactory)
 
 	// TODO: Martian for Backend
 
 	// Backend 호출에 대한 Rate Limit Middleware 설정
 	backendFactory = ratelimitProxy.BackendFactory(backendFactory)
 
-	// TODO: Circuit-Breaker for Backend
+	// Backend 호출에 대한 동시 처리 제한 (Bulkhead) Middleware 설정 (처리 중/대기 중인 요청 수는 Metrics로 수집)
+	backendFactory = bulkheadProxy.BackendFactory(backendFactory, mc.BulkheadReporter())
 
 	// Metrics 연동 기반의 BackendFactory 설정
 	backendFactory = mc.BackendFactory("backend", backendFactory)
@@ -3,9 +3,11 @@ package server
 
 import (
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
+	bulkheadProxy "github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/bulkhead/proxy"
 	ginMetrics "github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/metrics/gin"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/middlewares/opencensus"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/proxy"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/sd"
 )
 
 // ===== [ Constants and Variables ] =====
@@ -14,9 +16,18 @@ import (
 // ===== [ Private Functions ] =====
 // ===== [ Public Functions ] =====
 
-// setupGinProxyFactory - 지정한 Backend Factory를 연계하는 Proxy Factory 설정
+// setupGinProxyFactory - 지정한 Backend Factory를 연계하는 Proxy Factory 설정 (Mirror 설정이 지정된 Backend의 Shadow 요청은 Base Backend Factory로 처리)
 func setupGinProxyFactory(logger logging.Logger, bf proxy.BackendFactory, mc *ginMetrics.Collector) proxy.Factory {
-	proxyFactory := proxy.NewDefaultFactory(bf, logger)
+	// 트래픽 분할 Variant 별 처리 결과와 Backend 재 시도 결과, Circuit 상태 전환, Mirror 처리 결과는 Metrics로 수집
+	proxyFactory := proxy.NewDefaultFactoryWithMirror(bf, setupGinHTTPBackendFactory(), logger, sd.GetSubscriber, proxy.Reporters{
+		Variant: mc.VariantReporter(),
+		Retry:   mc.RetryReporter(),
+		Breaker: mc.BreakerReporter(),
+		Mirror:  mc.MirrorReporter(),
+	})
+
+	// Endpoint 처리에 대한 동시 처리 제한 (Bulkhead) 설정 (처리 중/대기 중인 요청 수는 Metrics로 수집)
+	proxyFactory = bulkheadProxy.ProxyFactory(proxyFactory, mc.BulkheadReporter())
 
 	// Metrics 연동 기반의 ProxyFactory 설정
 	proxyFactory = mc.ProxyFactory("proxy", proxyFactory)
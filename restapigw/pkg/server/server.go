@@ -14,6 +14,7 @@ import (
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/errors"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/logging"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/router"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/sd"
 	httpServer "github.com/cloud-barista/cb-apigw/restapigw/pkg/transport/http/server"
 )
 
@@ -73,6 +74,8 @@ func (s *Server) closeChannel() {
 func (s *Server) rebuildRouter() {
 	s.logger.Debug("[SERVER] Refreshing configuration")
 
+	// 이전 구성의 Circuit Breaker는 재 구성된 Balancer에서 재 사용된 경우만 유지
+	sd.ResetBreakers()
 	// 신규 라우팅 엔진 생성
 	s.router.UpdateEngine(s.serviceConfig)
 	// 변경된 Routing 규칙 적용
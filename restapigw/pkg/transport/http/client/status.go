@@ -3,8 +3,11 @@ package client
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
 	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
@@ -19,8 +22,11 @@ const (
 )
 
 var (
-	// ErrInvalidStatusCode - Response의 StatusCode 가 200이나 201이 아닌 경우에 반환할 기본 오류
+	// ErrInvalidStatusCode - Response의 StatusCode 가 정상 상태 코드 (기본값: 200, 201, 204) 가 아닌 경우에 반환할 기본 오류
 	ErrInvalidStatusCode = errors.New("Invalid status code")
+
+	// 기본 정상 상태 코드 목록
+	defaultSuccessCodes = []string{"200", "201", "204"}
 )
 
 // ===== [ Types ] =====
@@ -56,12 +62,10 @@ func (r HTTPResponseError) StatusCode() int {
 
 // ===== [ Private Functions ] =====
 
-// ===== [ Public Functions ] =====
-
-// DefaultHTTPStatusHandler - Request/Response 기준으로 StatusCode를 처리하는 기본 HTTPStatusHandler
-func DefaultHTTPStatusHandler(ctx context.Context, resp *http.Response) (*http.Response, error) {
+// handleStatus - 지정한 정상 상태 코드 목록을 기준으로 Response의 StatusCode 검증 (keepCode 가 true 면 Body가 없는 오류도 상태 코드 유지)
+func handleStatus(successCodes []string, keepCode bool, resp *http.Response) (*http.Response, error) {
 	// 오류 검증
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+	if !IsSuccessStatus(successCodes, resp.StatusCode) {
 		msg, err := core.GetResponseString(resp)
 		if err != nil {
 			return nil, ErrInvalidStatusCode
@@ -72,6 +76,11 @@ func DefaultHTTPStatusHandler(ctx context.Context, resp *http.Response) (*http.R
 			return resp, core.NewWrappedError(resp.StatusCode, msg, ErrInvalidStatusCode)
 		}
 
+		// 상태 코드 변환 규칙이 지정된 경우는 상태 코드 유지
+		if keepCode {
+			return nil, core.NewWrappedError(resp.StatusCode, ErrInvalidStatusCode.Error(), ErrInvalidStatusCode)
+		}
+
 		// 오류 반환
 		return nil, ErrInvalidStatusCode
 	}
@@ -80,6 +89,21 @@ func DefaultHTTPStatusHandler(ctx context.Context, resp *http.Response) (*http.R
 	return resp, nil
 }
 
+// ===== [ Public Functions ] =====
+
+// DefaultHTTPStatusHandler - Request/Response 기준으로 StatusCode를 처리하는 기본 HTTPStatusHandler
+func DefaultHTTPStatusHandler(ctx context.Context, resp *http.Response) (*http.Response, error) {
+	return handleStatus(defaultSuccessCodes, false, resp)
+}
+
+// NewHTTPStatusHandler - 지정한 상태 코드 목록 ("2xx" 와 같은 범위 지정 포함) 만 정상으로 처리하는 HTTPStatusHandler 생성
+// (keepCode 가 true 면 상태 코드 변환 규칙에 사용할 수 있도록 Body가 없는 오류도 Backend 상태 코드 유지)
+func NewHTTPStatusHandler(successCodes []string, keepCode bool) HTTPStatusHandler {
+	return func(ctx context.Context, resp *http.Response) (*http.Response, error) {
+		return handleStatus(successCodes, keepCode, resp)
+	}
+}
+
 // NoOpHTTPStatusHandler - NO-OP 처리가 설정된 경우의 HTTPSTatusHandler (별도 처리가 필요없는 경우)
 func NoOpHTTPStatusHandler(_ context.Context, resp *http.Response) (*http.Response, error) {
 	return resp, nil
@@ -109,18 +133,45 @@ func DetailedHTTPStatusHandler(next HTTPStatusHandler, name string) HTTPStatusHa
 	}
 }
 
+// IsSuccessStatus - 지정한 상태 코드가 정상 상태 코드 목록 ("2xx" 와 같은 범위 지정 포함) 에 포함되는지 검증
+func IsSuccessStatus(successCodes []string, code int) bool {
+	status := strconv.Itoa(code)
+	for _, c := range successCodes {
+		if len(c) == 3 && strings.HasSuffix(strings.ToLower(c), "xx") {
+			if c[0] == status[0] {
+				return true
+			}
+		} else if c == status {
+			return true
+		}
+	}
+	return false
+}
+
 // GetHTTPStatusHandler - Backend를 호출한 후의 Response State 처리
-// - "mw-http" Middleware 설정에 "return_error_details" 설정이 된 경우에는 DetailedHTTPStatusHandler를 사용 (내부적으로 DefaultHTTPStatusHandler 호출)
-// - 그외는 DefaultHTTPStatusHandler 사용
+// - "mw-http" Middleware 설정에 "success_codes" 설정이 된 경우는 지정한 상태 코드만 정상으로 처리 (기본값: 200, 201, 204)
+// - Endpoint에 "status_mapping" 설정이 된 경우는 Body가 없는 오류도 Backend 상태 코드 유지 (그외는 ErrInvalidStatusCode)
+// - "mw-http" Middleware 설정에 "return_error_details" 설정이 된 경우에는 DetailedHTTPStatusHandler를 사용 (내부적으로 정상 상태 코드 검증 처리)
 func GetHTTPStatusHandler(bConf *config.BackendConfig) HTTPStatusHandler {
+	codes := defaultSuccessCodes
+	details := ""
 	if e, ok := bConf.Middleware[MWNamespace]; ok {
 		if m, ok := e.(config.MWConfig); ok {
-			if v, ok := m["return_error_details"]; ok {
-				if b, ok := v.(string); ok && b != "" {
-					return DetailedHTTPStatusHandler(DefaultHTTPStatusHandler, b)
+			if vs, ok := m["success_codes"].([]interface{}); ok && len(vs) > 0 {
+				codes = make([]string, len(vs))
+				for i, v := range vs {
+					codes[i] = fmt.Sprintf("%v", v)
 				}
 			}
+			if b, ok := m["return_error_details"].(string); ok {
+				details = b
+			}
 		}
 	}
-	return DefaultHTTPStatusHandler
+
+	handler := NewHTTPStatusHandler(codes, bConf.KeepStatusCode)
+	if details != "" {
+		return DetailedHTTPStatusHandler(handler, details)
+	}
+	return handler
 }
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/config"
+	"github.com/cloud-barista/cb-apigw/restapigw/pkg/core"
+)
+
+func newStatusResponse(code int, body string) *http.Response {
+	return &http.Response{StatusCode: code, Body: ioutil.NopCloser(strings.NewReader(body))}
+}
+
+func TestGetHTTPStatusHandler(t *testing.T) {
+	successCodes := config.MWConfig{MWNamespace: config.MWConfig{"success_codes": []interface{}{"2xx"}}}
+
+	for _, tc := range []struct {
+		name      string
+		bConf     *config.BackendConfig
+		code      int
+		body      string
+		success   bool
+		errorCode int // 0 이면 ErrInvalidStatusCode
+	}{
+		{name: "default 200", bConf: &config.BackendConfig{}, code: http.StatusOK, success: true},
+		{name: "default 204", bConf: &config.BackendConfig{}, code: http.StatusNoContent, success: true},
+		{name: "default 202", bConf: &config.BackendConfig{}, code: http.StatusAccepted},
+		{name: "default 302", bConf: &config.BackendConfig{}, code: http.StatusFound},
+		{name: "default 404 with body", bConf: &config.BackendConfig{}, code: http.StatusNotFound, body: "not found", errorCode: http.StatusNotFound},
+		{name: "success_codes 204", bConf: &config.BackendConfig{Middleware: successCodes}, code: http.StatusNoContent, success: true},
+		{name: "success_codes 304", bConf: &config.BackendConfig{Middleware: successCodes}, code: http.StatusNotModified},
+		{name: "status_mapping 302", bConf: &config.BackendConfig{KeepStatusCode: true}, code: http.StatusFound, errorCode: http.StatusFound},
+		{name: "status_mapping 404", bConf: &config.BackendConfig{KeepStatusCode: true}, code: http.StatusNotFound, errorCode: http.StatusNotFound},
+	} {
+		resp, err := GetHTTPStatusHandler(tc.bConf)(context.Background(), newStatusResponse(tc.code, tc.body))
+		if tc.success {
+			if err != nil || resp == nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+
+		if tc.errorCode == 0 {
+			if err != ErrInvalidStatusCode {
+				t.Errorf("%s: expected ErrInvalidStatusCode, but %v", tc.name, err)
+			}
+			continue
+		}
+		we, ok := err.(core.WrappedError)
+		if !ok {
+			t.Errorf("%s: expected wrapped error, but %v", tc.name, err)
+			continue
+		}
+		if we.Code() != tc.errorCode {
+			t.Errorf("%s: unexpected error status code %d", tc.name, we.Code())
+		}
+	}
+}